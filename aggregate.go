@@ -0,0 +1,238 @@
+package sortedmap
+
+import "errors"
+
+const noAggregatorErr = "No aggregator was set using the Augment option."
+
+// CombineFunc defines the type of an associative function that merges two partial aggregates.
+// The aggregate of the lower values is always passed as a.
+type CombineFunc func(a, b interface{}) interface{}
+
+// MetricFunc defines the type of function used to derive a numeric metric from a value.
+type MetricFunc func(val interface{}) float64
+
+// Aggregator defines a monoid that is maintained over the collection's values, so that aggregates can be computed over any range of values.
+// Metric derives each value's aggregate, Combine merges aggregates of neighbouring ranges, and Identity is the aggregate of an empty range,
+// such that Combine(Identity, x) and Combine(x, Identity) both equal x.
+type Aggregator struct {
+	Identity interface{}
+	Metric   ExtractFunc
+	Combine  CombineFunc
+}
+
+// CountAggregator returns an Aggregator that counts values, as an int.
+func CountAggregator() Aggregator {
+	return Aggregator{
+		Identity: 0,
+		Metric: func(interface{}) interface{} {
+			return 1
+		},
+		Combine: func(a, b interface{}) interface{} {
+			return a.(int) + b.(int)
+		},
+	}
+}
+
+// SumAggregator returns an Aggregator that sums the metric of each value, as a float64.
+func SumAggregator(metric MetricFunc) Aggregator {
+	return Aggregator{
+		Identity: float64(0),
+		Metric: func(val interface{}) interface{} {
+			return metric(val)
+		},
+		Combine: func(a, b interface{}) interface{} {
+			return a.(float64) + b.(float64)
+		},
+	}
+}
+
+// MeanResult contains the sum and count of the metrics aggregated by MeanAggregator.
+type MeanResult struct {
+	Sum   float64
+	Count int
+}
+
+// Mean returns the arithmetic mean of the aggregated metrics, or zero if no metrics were aggregated.
+func (m MeanResult) Mean() float64 {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.Sum / float64(m.Count)
+}
+
+// MeanAggregator returns an Aggregator that averages the metric of each value, as a MeanResult.
+func MeanAggregator(metric MetricFunc) Aggregator {
+	return Aggregator{
+		Identity: MeanResult{},
+		Metric: func(val interface{}) interface{} {
+			return MeanResult{Sum: metric(val), Count: 1}
+		},
+		Combine: func(a, b interface{}) interface{} {
+			ma, mb := a.(MeanResult), b.(MeanResult)
+			return MeanResult{Sum: ma.Sum + mb.Sum, Count: ma.Count + mb.Count}
+		},
+	}
+}
+
+func extremeAggregator(extract ExtractFunc, keepB func(a, b interface{}) bool) Aggregator {
+	return Aggregator{
+		Identity: nil,
+		Metric:   extract,
+		Combine: func(a, b interface{}) interface{} {
+			if a == nil || (b != nil && keepB(a, b)) {
+				return b
+			}
+			return a
+		},
+	}
+}
+
+// MinAggregator returns an Aggregator that selects the least part of each value selected by extract, using lessFn.
+// The aggregate of an empty range is nil.
+func MinAggregator(extract ExtractFunc, lessFn ComparisonFunc) Aggregator {
+	return extremeAggregator(extract, func(a, b interface{}) bool {
+		return lessFn(b, a)
+	})
+}
+
+// MaxAggregator returns an Aggregator that selects the greatest part of each value selected by extract, using lessFn.
+// The aggregate of an empty range is nil.
+func MaxAggregator(extract ExtractFunc, lessFn ComparisonFunc) Aggregator {
+	return extremeAggregator(extract, func(a, b interface{}) bool {
+		return lessFn(a, b)
+	})
+}
+
+// Augment returns an Option that maintains the given Aggregator over the collection's values,
+// so that Aggregate can answer queries over any range of values in O(log n) time.
+// The metric of each value is computed once, when it is added, but the aggregates are kept by position,
+// so each change combines the aggregates of every position it shifts, which takes O(n) time in the worst case.
+func Augment(agg Aggregator) Option {
+	return func(sm *SortedMap) {
+		sm.agg = newAggTree(agg)
+	}
+}
+
+// aggTree is a segment tree over the positions of the collection's sorted keys.
+// The metrics of the values are kept in leaves, parallel to the sorted keys, and are copied to nodes size+i,
+// while each internal node i holds the combined aggregate of nodes 2i and 2i+1.
+type aggTree struct {
+	agg    Aggregator
+	size   int
+	n      int
+	dirty  int
+	leaves []interface{}
+	nodes  []interface{}
+}
+
+func newAggTree(agg Aggregator) *aggTree {
+	return &aggTree{agg: agg}
+}
+
+func (t *aggTree) markDirty(i int) {
+	if i < t.dirty {
+		t.dirty = i
+	}
+}
+
+// insertAggregate adds the metric of val at position i, which is recomputed by the next call to updateAggregate.
+func (sm *SortedMap) insertAggregate(i int, val interface{}) {
+	if sm.agg != nil {
+		sm.agg.leaves = insertInterface(sm.agg.leaves, sm.agg.agg.Metric(val), i)
+		sm.agg.markDirty(i)
+	}
+}
+
+// deleteAggregate removes the metric at position i, which is recomputed by the next call to updateAggregate.
+func (sm *SortedMap) deleteAggregate(i int) {
+	if sm.agg != nil {
+		sm.agg.leaves = deleteInterface(sm.agg.leaves, i)
+		sm.agg.markDirty(i)
+	}
+}
+
+// resetAggregate recomputes the metrics of all values, after the sorted keys were replaced or re-sorted.
+func (sm *SortedMap) resetAggregate() {
+	if sm.agg != nil {
+		sm.agg.leaves = make([]interface{}, len(sm.sorted))
+		for i, key := range sm.sorted {
+			sm.agg.leaves[i] = sm.agg.agg.Metric(sm.idx[key])
+		}
+		sm.agg.markDirty(0)
+		sm.updateAggregate()
+	}
+}
+
+// updateAggregate recomputes the aggregates of the positions changed since the last update.
+// It is called once at the end of each change, so that Aggregate only reads the tree.
+func (sm *SortedMap) updateAggregate() {
+	if sm.agg != nil {
+		sm.agg.update()
+	}
+}
+
+// update copies the stale leaves into the tree and recombines their ancestors.
+func (t *aggTree) update() {
+	n := len(t.leaves)
+	if n > t.size {
+		size := 1
+		for size < n {
+			size <<= 1
+		}
+		t.size, t.n, t.dirty = size, 0, 0
+		t.nodes = make([]interface{}, 2*size)
+		for i := range t.nodes {
+			t.nodes[i] = t.agg.Identity
+		}
+	}
+
+	end := n
+	if t.n > end {
+		end = t.n
+	}
+	if t.dirty < end {
+		for i := t.dirty; i < end; i++ {
+			if i < n {
+				t.nodes[t.size+i] = t.leaves[i]
+			} else {
+				t.nodes[t.size+i] = t.agg.Identity
+			}
+		}
+		for l, r := (t.size+t.dirty)>>1, (t.size+end-1)>>1; l > 0; l, r = l>>1, r>>1 {
+			for i := l; i <= r; i++ {
+				t.nodes[i] = t.agg.Combine(t.nodes[2*i], t.nodes[2*i+1])
+			}
+		}
+	}
+
+	t.n, t.dirty = n, n
+}
+
+// query combines the leaves in the half-open range [l, r), in order.
+func (t *aggTree) query(l, r int) interface{} {
+	lowerAgg, upperAgg := t.agg.Identity, t.agg.Identity
+	for l, r = l+t.size, r+t.size; l < r; l, r = l>>1, r>>1 {
+		if l&1 == 1 {
+			lowerAgg = t.agg.Combine(lowerAgg, t.nodes[l])
+			l++
+		}
+		if r&1 == 1 {
+			r--
+			upperAgg = t.agg.Combine(t.nodes[r], upperAgg)
+		}
+	}
+	return t.agg.Combine(lowerAgg, upperAgg)
+}
+
+// Aggregate returns the aggregate of the values within the given bounds, which are selected in the same way as BoundedKeys,
+// using the Aggregator given to the Augment option. Either bound can be nil to leave the range unbounded.
+func (sm *SortedMap) Aggregate(lowerBound, upperBound interface{}) (interface{}, error) {
+	if sm.agg == nil {
+		return nil, errors.New(noAggregatorErr)
+	}
+	iterBounds := sm.boundsIdxSearch(lowerBound, upperBound)
+	if iterBounds == nil {
+		return sm.agg.agg.Identity, errors.New(noValuesErr)
+	}
+	return sm.agg.query(iterBounds[0], iterBounds[1]+1), nil
+}
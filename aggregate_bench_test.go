@@ -0,0 +1,27 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func replaceMiddleRecord(b *testing.B, opts ...Option) {
+	sm := New(0, asc.Int, opts...)
+	for i := 0; i < 10000; i++ {
+		sm.Insert(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sm.Replace(5000, 5000+i%2)
+	}
+}
+
+func BenchmarkReplaceMiddleOf10000Records(b *testing.B) {
+	replaceMiddleRecord(b)
+}
+
+func BenchmarkReplaceMiddleOf10000RecordsWithSumAggregator(b *testing.B) {
+	replaceMiddleRecord(b, Augment(SumAggregator(aggTestMetric)))
+}
@@ -0,0 +1,154 @@
+package sortedmap
+
+import (
+	"fmt"
+	mrand "math/rand"
+	"sync"
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func aggTestMetric(val interface{}) float64 {
+	return float64(val.(int))
+}
+
+// concatAggregator is not commutative, so it verifies that values are combined in sorted order.
+func concatAggregator() Aggregator {
+	return Aggregator{
+		Identity: "",
+		Metric: func(val interface{}) interface{} {
+			return fmt.Sprintf("%v,", val)
+		},
+		Combine: func(a, b interface{}) interface{} {
+			return a.(string) + b.(string)
+		},
+	}
+}
+
+func verifyAggregate(sm *SortedMap, lowerBound, upperBound interface{}) error {
+	expected := ""
+	sm.BoundedIterFunc(false, lowerBound, upperBound, func(rec Record) bool {
+		expected += fmt.Sprintf("%v,", rec.Val)
+		return true
+	})
+
+	agg, err := sm.Aggregate(lowerBound, upperBound)
+	if expected == "" {
+		if err == nil {
+			return fmt.Errorf("expected an error for bounds (%v, %v)", lowerBound, upperBound)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if agg != expected {
+		return fmt.Errorf("aggregate mismatch for bounds (%v, %v). Expected: %v, Had: %v.", lowerBound, upperBound, expected, agg)
+	}
+	return nil
+}
+
+func TestAggregate(t *testing.T) {
+	sm := New(0, asc.Int, Augment(concatAggregator()))
+
+	for i := 0; i < 2000; i++ {
+		switch op := mrand.Intn(10); {
+		case op < 5:
+			sm.Insert(mrand.Intn(500), mrand.Intn(1000))
+		case op < 7:
+			sm.Replace(mrand.Intn(500), mrand.Intn(1000))
+		case op < 9:
+			sm.Delete(mrand.Intn(500))
+		default:
+			lowerBound := mrand.Intn(1000)
+			sm.BoundedDelete(lowerBound, lowerBound+mrand.Intn(20))
+		}
+
+		if i%10 == 0 {
+			lowerBound := mrand.Intn(1000)
+			for _, bounds := range [][]interface{}{
+				{lowerBound, lowerBound + mrand.Intn(300)}, {nil, lowerBound}, {lowerBound, nil}, {nil, nil},
+			} {
+				if err := verifyAggregate(sm, bounds[0], bounds[1]); err != nil {
+					t.Fatalf("TestAggregate failed after %v changes: %v", i, err)
+				}
+			}
+		}
+	}
+
+	clone := sm.Clone()
+	clone.Insert(1000, 1000)
+	if err := verifyAggregate(clone, nil, nil); err != nil {
+		t.Fatalf("TestAggregate failed: %v", err)
+	}
+	if err := verifyAggregate(sm, nil, nil); err != nil {
+		t.Fatalf("TestAggregate failed: %v", err)
+	}
+
+	if _, err := New(0, asc.Int).Aggregate(nil, nil); err == nil {
+		t.Fatal("TestAggregate failed: no error was returned without an aggregator.")
+	}
+}
+
+func TestAggregateConcurrentReads(t *testing.T) {
+	sm := New(0, asc.Int, Augment(CountAggregator()))
+	for i := 0; i < 1000; i++ {
+		sm.Insert(i, 2*i)
+	}
+	sm.Delete(500)
+	sm.SetComparisonFunc(desc.Int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if agg, err := sm.Aggregate(1801-2*i, 201+2*i); err != nil || agg != 799-2*i {
+				t.Errorf("TestAggregateConcurrentReads failed: Expected: %v, Had: %v, %v.", 799-2*i, agg, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestBuiltInAggregators(t *testing.T) {
+	vals := []int{4, 8, 15, 16, 23, 42}
+	aggs := map[string]Aggregator{
+		"count": CountAggregator(),
+		"sum":   SumAggregator(aggTestMetric),
+		"mean":  MeanAggregator(aggTestMetric),
+		"min":   MinAggregator(func(val interface{}) interface{} { return val.(int) % 10 }, asc.Int),
+		"max":   MaxAggregator(func(val interface{}) interface{} { return val.(int) % 10 }, asc.Int),
+	}
+	expected := map[string]interface{}{
+		"count": 4,
+		"sum":   float64(62),
+		"mean":  15.5,
+		"min":   3,
+		"max":   8,
+	}
+
+	for name, agg := range aggs {
+		sm := New(0, asc.Int, Augment(agg))
+		for i, val := range vals {
+			sm.Insert(i, val)
+		}
+
+		result, err := sm.Aggregate(5, 23)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mean, ok := result.(MeanResult); ok {
+			result = mean.Mean()
+		}
+		if result != expected[name] {
+			t.Fatalf("TestBuiltInAggregators failed: %v aggregate. Expected: %v, Had: %v.", name, expected[name], result)
+		}
+	}
+
+	if (MeanResult{}).Mean() != 0 {
+		t.Fatal("TestBuiltInAggregators failed: the mean of no values was not zero.")
+	}
+}
@@ -0,0 +1,39 @@
+package asc
+
+import "math/big"
+
+// compareNils orders nil values before all other values, treating them as equal to each other.
+// ok is false if neither value is nil.
+func compareNils(iNil, jNil bool) (less, ok bool) {
+	if iNil || jNil {
+		return iNil && !jNil, true
+	}
+	return false, false
+}
+
+// BigInt is a less than comparison function for the *big.Int type, where nil is less than all other values.
+func BigInt(i, j interface{}) bool {
+	x, y := i.(*big.Int), j.(*big.Int)
+	if less, ok := compareNils(x == nil, y == nil); ok {
+		return less
+	}
+	return x.Cmp(y) < 0
+}
+
+// BigFloat is a less than comparison function for the *big.Float type, where nil is less than all other values.
+func BigFloat(i, j interface{}) bool {
+	x, y := i.(*big.Float), j.(*big.Float)
+	if less, ok := compareNils(x == nil, y == nil); ok {
+		return less
+	}
+	return x.Cmp(y) < 0
+}
+
+// BigRat is a less than comparison function for the *big.Rat type, where nil is less than all other values.
+func BigRat(i, j interface{}) bool {
+	x, y := i.(*big.Rat), j.(*big.Rat)
+	if less, ok := compareNils(x == nil, y == nil); ok {
+		return less
+	}
+	return x.Cmp(y) < 0
+}
@@ -0,0 +1,33 @@
+package asc
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	if BigInt(big.NewInt(1), big.NewInt(0)) {
+		t.Fatalf("asc.TestBigInt failed: %v\n", greaterThanErr)
+	}
+	if BigInt(big.NewInt(0), (*big.Int)(nil)) || BigInt((*big.Int)(nil), (*big.Int)(nil)) {
+		t.Fatalf("asc.TestBigInt failed: %v\n", nilErr)
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	if BigFloat(big.NewFloat(1), big.NewFloat(0.5)) {
+		t.Fatalf("asc.TestBigFloat failed: %v\n", greaterThanErr)
+	}
+	if BigFloat(big.NewFloat(0), (*big.Float)(nil)) || BigFloat((*big.Float)(nil), (*big.Float)(nil)) {
+		t.Fatalf("asc.TestBigFloat failed: %v\n", nilErr)
+	}
+}
+
+func TestBigRat(t *testing.T) {
+	if BigRat(big.NewRat(1, 2), big.NewRat(1, 3)) {
+		t.Fatalf("asc.TestBigRat failed: %v\n", greaterThanErr)
+	}
+	if BigRat(big.NewRat(0, 1), (*big.Rat)(nil)) || BigRat((*big.Rat)(nil), (*big.Rat)(nil)) {
+		t.Fatalf("asc.TestBigRat failed: %v\n", nilErr)
+	}
+}
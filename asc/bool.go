@@ -0,0 +1,6 @@
+package asc
+
+// Bool is a less than comparison function for the bool type, where false is less than true.
+func Bool(i, j interface{}) bool {
+	return !i.(bool) && j.(bool)
+}
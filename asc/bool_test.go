@@ -0,0 +1,9 @@
+package asc
+
+import "testing"
+
+func TestBool(t *testing.T) {
+	if Bool(true, false) {
+		t.Fatalf("asc.TestBool failed: %v\n", greaterThanErr)
+	}
+}
@@ -0,0 +1,99 @@
+package asc
+
+import (
+	"math"
+	"time"
+)
+
+func uint64Distance(i, j uint64) float64 {
+	if i > j {
+		return float64(i - j)
+	}
+	return float64(j - i)
+}
+
+func int64Distance(i, j int64) float64 {
+	if i > j {
+		return float64(uint64(i) - uint64(j))
+	}
+	return float64(uint64(j) - uint64(i))
+}
+
+// Uint8Distance is a distance function for the Uint8 numeric type.
+func Uint8Distance(i, j interface{}) float64 {
+	return uint64Distance(uint64(i.(uint8)), uint64(j.(uint8)))
+}
+
+// Uint16Distance is a distance function for the Uint16 numeric type.
+func Uint16Distance(i, j interface{}) float64 {
+	return uint64Distance(uint64(i.(uint16)), uint64(j.(uint16)))
+}
+
+// Uint32Distance is a distance function for the Uint32 numeric type.
+func Uint32Distance(i, j interface{}) float64 {
+	return uint64Distance(uint64(i.(uint32)), uint64(j.(uint32)))
+}
+
+// Uint64Distance is a distance function for the Uint64 numeric type.
+func Uint64Distance(i, j interface{}) float64 {
+	return uint64Distance(i.(uint64), j.(uint64))
+}
+
+// UintDistance is a distance function for the Uint numeric type.
+func UintDistance(i, j interface{}) float64 {
+	return uint64Distance(uint64(i.(uint)), uint64(j.(uint)))
+}
+
+// Int8Distance is a distance function for the Int8 numeric type.
+func Int8Distance(i, j interface{}) float64 {
+	return int64Distance(int64(i.(int8)), int64(j.(int8)))
+}
+
+// Int16Distance is a distance function for the Int16 numeric type.
+func Int16Distance(i, j interface{}) float64 {
+	return int64Distance(int64(i.(int16)), int64(j.(int16)))
+}
+
+// Int32Distance is a distance function for the Int32 numeric type.
+func Int32Distance(i, j interface{}) float64 {
+	return int64Distance(int64(i.(int32)), int64(j.(int32)))
+}
+
+// Int64Distance is a distance function for the Int64 numeric type.
+func Int64Distance(i, j interface{}) float64 {
+	return int64Distance(i.(int64), j.(int64))
+}
+
+// IntDistance is a distance function for the Int numeric type.
+func IntDistance(i, j interface{}) float64 {
+	return int64Distance(int64(i.(int)), int64(j.(int)))
+}
+
+// Float32Distance is a distance function for the Float32 numeric type.
+func Float32Distance(i, j interface{}) float64 {
+	return math.Abs(float64(i.(float32)) - float64(j.(float32)))
+}
+
+// Float64Distance is a distance function for the Float64 numeric type.
+func Float64Distance(i, j interface{}) float64 {
+	return math.Abs(i.(float64) - j.(float64))
+}
+
+// DurationDistance is a distance function for the time.Duration type, in nanoseconds.
+func DurationDistance(i, j interface{}) float64 {
+	return int64Distance(int64(i.(time.Duration)), int64(j.(time.Duration)))
+}
+
+// TimeDistance is a distance function for the time.Time type, in nanoseconds.
+// Distances greater than about 292 years are limited to the largest time.Duration.
+func TimeDistance(i, j interface{}) float64 {
+	d := i.(time.Time).Sub(j.(time.Time))
+	if d < 0 {
+		d = -d
+	}
+	if d < 0 {
+		// -d overflowed for the smallest time.Duration.
+		d = math.MaxInt64
+	}
+	return float64(d)
+}
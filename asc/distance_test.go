@@ -0,0 +1,38 @@
+package asc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		name     string
+		dist     func(i, j interface{}) float64
+		i, j     interface{}
+		expected float64
+	}{
+		{"Uint8", Uint8Distance, uint8(3), uint8(250), 247},
+		{"Uint16", Uint16Distance, uint16(3), uint16(1), 2},
+		{"Uint32", Uint32Distance, uint32(3), uint32(1), 2},
+		{"Uint64", Uint64Distance, uint64(math.MaxUint64), uint64(0), math.MaxUint64},
+		{"Uint", UintDistance, uint(1), uint(3), 2},
+		{"Int8", Int8Distance, int8(-128), int8(127), 255},
+		{"Int16", Int16Distance, int16(-1), int16(1), 2},
+		{"Int32", Int32Distance, int32(-1), int32(1), 2},
+		{"Int64", Int64Distance, int64(math.MinInt64), int64(math.MaxInt64), math.MaxUint64},
+		{"Int", IntDistance, 5, -5, 10},
+		{"Float32", Float32Distance, float32(1.5), float32(-1), 2.5},
+		{"Float64", Float64Distance, -1.5, 1.0, 2.5},
+		{"Duration", DurationDistance, time.Second, time.Minute, float64(59 * time.Second)},
+		{"Time", TimeDistance, time.Unix(60, 0), time.Unix(0, 0), float64(time.Minute)},
+		{"Time", TimeDistance, time.Unix(0, 0), time.Unix(60, 0), float64(time.Minute)},
+	}
+
+	for _, c := range cases {
+		if d := c.dist(c.i, c.j); d != c.expected {
+			t.Fatalf("asc.TestDistance failed: %vDistance(%v, %v). Expected: %v, Had: %v.\n", c.name, c.i, c.j, c.expected, d)
+		}
+	}
+}
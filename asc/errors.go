@@ -1,3 +1,6 @@
 package asc
 
-const greaterThanErr = "the higher value was less than the lesser value!"
+const (
+	greaterThanErr = "the higher value was less than the lesser value!"
+	nilErr         = "a nil value was not less than all other values!"
+)
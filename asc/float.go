@@ -0,0 +1,26 @@
+package asc
+
+import "math"
+
+func float64Total(x, y float64) bool {
+	xNaN, yNaN := math.IsNaN(x), math.IsNaN(y)
+	if xNaN || yNaN {
+		return !xNaN && yNaN
+	}
+	if x == y {
+		return math.Signbit(x) && !math.Signbit(y)
+	}
+	return x < y
+}
+
+// Float64Total is a less than comparison function for the Float64 numeric type that is safe to use with NaN values.
+// -0 is less than +0, and NaN values are greater than all other values and are equal to each other.
+func Float64Total(i, j interface{}) bool {
+	return float64Total(i.(float64), j.(float64))
+}
+
+// Float32Total is a less than comparison function for the Float32 numeric type that is safe to use with NaN values.
+// -0 is less than +0, and NaN values are greater than all other values and are equal to each other.
+func Float32Total(i, j interface{}) bool {
+	return float64Total(float64(i.(float32)), float64(j.(float32)))
+}
@@ -0,0 +1,35 @@
+package asc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64Total(t *testing.T) {
+	nan, negZero := math.NaN(), math.Copysign(0, -1)
+	ordered := []float64{math.Inf(-1), -1, negZero, 0, 1, math.Inf(1), nan}
+
+	for i := range ordered {
+		for j := range ordered {
+			if Float64Total(ordered[i], ordered[j]) != (i < j) {
+				t.Fatalf("asc.TestFloat64Total failed: Float64Total(%v, %v) should be %v\n", ordered[i], ordered[j], i < j)
+			}
+		}
+	}
+	if Float64Total(nan, math.NaN()) {
+		t.Fatal("asc.TestFloat64Total failed: NaN values were not equal")
+	}
+}
+
+func TestFloat32Total(t *testing.T) {
+	nan, negZero := float32(math.NaN()), float32(math.Copysign(0, -1))
+	ordered := []float32{float32(math.Inf(-1)), -1, negZero, 0, 1, float32(math.Inf(1)), nan}
+
+	for i := range ordered {
+		for j := range ordered {
+			if Float32Total(ordered[i], ordered[j]) != (i < j) {
+				t.Fatalf("asc.TestFloat32Total failed: Float32Total(%v, %v) should be %v\n", ordered[i], ordered[j], i < j)
+			}
+		}
+	}
+}
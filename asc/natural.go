@@ -0,0 +1,100 @@
+package asc
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// digitVal returns the value of a Unicode decimal digit.
+// Decimal digits are encoded in contiguous runs of ten, starting at zero, so the value is the offset from the start of the run.
+func digitVal(r rune) byte {
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+	return byte((r - start) % 10)
+}
+
+// digitRun splits s after its leading run of digits, returning the digit values without leading zeros.
+func digitRun(s string) ([]byte, string) {
+	digits := make([]byte, 0, len(s))
+	for s != "" {
+		r, size := utf8.DecodeRuneInString(s)
+		if !unicode.IsDigit(r) {
+			break
+		}
+		if d := digitVal(r); d != 0 || len(digits) > 0 {
+			digits = append(digits, d)
+		}
+		s = s[size:]
+	}
+	return digits, s
+}
+
+func compareDigitRuns(a, b []byte) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(a, b)
+}
+
+// naturalCompare compares strings by splitting them into runs of digits, which are compared numerically,
+// and other characters, which are compared case-insensitively.
+func naturalCompare(s, t string) int {
+	for s != "" && t != "" {
+		sr, sSize := utf8.DecodeRuneInString(s)
+		tr, tSize := utf8.DecodeRuneInString(t)
+		sDigit, tDigit := unicode.IsDigit(sr), unicode.IsDigit(tr)
+
+		switch {
+		case sDigit && tDigit:
+			var sDigits, tDigits []byte
+			sDigits, s = digitRun(s)
+			tDigits, t = digitRun(t)
+
+			if c := compareDigitRuns(sDigits, tDigits); c != 0 {
+				return c
+			}
+			continue
+
+		case sDigit:
+			return -1
+
+		case tDigit:
+			return 1
+		}
+
+		if sf, tf := foldRune(sr), foldRune(tr); sf != tf {
+			if sf < tf {
+				return -1
+			}
+			return 1
+		}
+		s, t = s[sSize:], t[tSize:]
+	}
+
+	switch {
+	case s == "" && t != "":
+		return -1
+	case s != "" && t == "":
+		return 1
+	}
+	return 0
+}
+
+// Natural is a less than comparison function for the string type that orders strings the way people read them.
+// Runs of digits, including other Unicode decimal digits, are compared by their numeric value, so "item2" is less than "item10".
+// Other characters are compared case-insensitively.
+// Strings that are otherwise equal, such as "item01" and "item1" or "A" and "a", are ordered by their bytes.
+func Natural(i, j interface{}) bool {
+	s, t := i.(string), j.(string)
+	if c := naturalCompare(s, t); c != 0 {
+		return c < 0
+	}
+	return strings.Compare(s, t) < 0
+}
@@ -0,0 +1,42 @@
+package asc
+
+import "testing"
+
+func TestNatural(t *testing.T) {
+	if Natural("item10", "item2") {
+		t.Fatalf("asc.TestNatural failed: %v\n", greaterThanErr)
+	}
+
+	ordered := []string{
+		"",
+		"1",
+		"2",
+		"10",
+		"a",
+		"file",
+		"file01.txt",
+		"file1.txt",
+		"File2.txt",
+		"file2.txt",
+		"file２.txt",
+		"file3",
+		"file003a",
+		"file3b",
+		"File10.txt",
+		"file10.txt",
+		"file١١",
+		"item",
+		"Item9",
+		"item10",
+		"item9999999999999999999999",
+		"item10000000000000000000000",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			if Natural(ordered[i], ordered[j]) != (i < j) {
+				t.Fatalf("asc.TestNatural failed: Natural(%q, %q) should be %v\n", ordered[i], ordered[j], i < j)
+			}
+		}
+	}
+}
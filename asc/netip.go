@@ -0,0 +1,20 @@
+package asc
+
+import "net/netip"
+
+// Addr is a less than comparison function for the netip.Addr type.
+// The zero Addr is less than all other values and IPv4 addresses are less than IPv6 addresses.
+func Addr(i, j interface{}) bool {
+	return i.(netip.Addr).Less(j.(netip.Addr))
+}
+
+// Prefix is a less than comparison function for the netip.Prefix type.
+// Prefixes are ordered by their address, as with Addr, and then by their length, so that shorter prefixes are less.
+// The zero Prefix is less than all other values.
+func Prefix(i, j interface{}) bool {
+	p, q := i.(netip.Prefix), j.(netip.Prefix)
+	if c := p.Addr().Compare(q.Addr()); c != 0 {
+		return c < 0
+	}
+	return p.Bits() < q.Bits()
+}
@@ -0,0 +1,30 @@
+package asc
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestAddr(t *testing.T) {
+	if Addr(netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("10.0.0.1")) {
+		t.Fatalf("asc.TestAddr failed: %v\n", greaterThanErr)
+	}
+	if Addr(netip.MustParseAddr("::1"), netip.MustParseAddr("10.0.0.1")) {
+		t.Fatalf("asc.TestAddr failed: %v\n", greaterThanErr)
+	}
+	if Addr(netip.MustParseAddr("0.0.0.0"), netip.Addr{}) {
+		t.Fatalf("asc.TestAddr failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	if Prefix(netip.MustParsePrefix("10.0.1.0/24"), netip.MustParsePrefix("10.0.0.0/16")) {
+		t.Fatalf("asc.TestPrefix failed: %v\n", greaterThanErr)
+	}
+	if Prefix(netip.MustParsePrefix("10.0.0.0/24"), netip.MustParsePrefix("10.0.0.0/16")) {
+		t.Fatalf("asc.TestPrefix failed: %v\n", greaterThanErr)
+	}
+	if Prefix(netip.MustParsePrefix("0.0.0.0/0"), netip.Prefix{}) {
+		t.Fatalf("asc.TestPrefix failed: %v\n", greaterThanErr)
+	}
+}
@@ -73,3 +73,15 @@ func TestInt(t *testing.T) {
 		t.Fatalf("asc.TestInt failed: %v\n", greaterThanErr)
 	}
 }
+
+func TestComplex64(t *testing.T) {
+	if Complex64(complex64(-2i), complex64(1)) {
+		t.Fatalf("asc.TestComplex64 failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestComplex128(t *testing.T) {
+	if Complex128(complex128(-2i), complex128(1)) {
+		t.Fatalf("asc.TestComplex128 failed: %v\n", greaterThanErr)
+	}
+}
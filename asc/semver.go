@@ -0,0 +1,147 @@
+package asc
+
+import "strings"
+
+type semVer struct {
+	core [3]string
+	pre  []string
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericID reports whether s is a numeric identifier, which must not include leading zeros.
+func isNumericID(s string) bool {
+	return isNumeric(s) && (len(s) == 1 || s[0] != '0')
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSemVer parses a semantic version string, with an optional "v" prefix.
+// Build metadata is ignored, because it does not affect precedence.
+// Numeric identifiers with leading zeros are not valid, as required by the specification.
+func parseSemVer(s string) (semVer, bool) {
+	v := semVer{}
+
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.pre = strings.Split(s[i+1:], ".")
+		for _, id := range v.pre {
+			if !isIdentifier(id) || isNumeric(id) && !isNumericID(id) {
+				return v, false
+			}
+		}
+		s = s[:i]
+	}
+
+	core := strings.Split(s, ".")
+	if len(core) != len(v.core) {
+		return v, false
+	}
+	for i, n := range core {
+		if !isNumericID(n) {
+			return v, false
+		}
+		v.core[i] = n
+	}
+	return v, true
+}
+
+func compareNumeric(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func comparePreRelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, bNum := isNumeric(a[i]), isNumeric(b[i])
+
+		var c int
+		switch {
+		case aNum && bNum:
+			c = compareNumeric(a[i], b[i])
+		case aNum:
+			c = -1
+		case bNum:
+			c = 1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+func compareSemVer(a, b semVer) int {
+	for i := range a.core {
+		if c := compareNumeric(a.core[i], b.core[i]); c != 0 {
+			return c
+		}
+	}
+	return comparePreRelease(a.pre, b.pre)
+}
+
+// SemVer is a less than comparison function for semantic version strings, such as "v1.2.3-rc.1+build.5".
+// Versions are ordered using the precedence rules of Semantic Versioning 2.0.0, where pre-release versions
+// are less than their release and build metadata is ignored.
+// Strings that are not valid versions, including versions with leading zeros such as "01.2.3",
+// are less than all valid versions and are ordered by their bytes.
+func SemVer(i, j interface{}) bool {
+	s, t := i.(string), j.(string)
+
+	sv, sOk := parseSemVer(s)
+	tv, tOk := parseSemVer(t)
+
+	switch {
+	case sOk && tOk:
+		return compareSemVer(sv, tv) < 0
+	case sOk || tOk:
+		return tOk
+	}
+	return s < t
+}
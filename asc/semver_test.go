@@ -0,0 +1,45 @@
+package asc
+
+import "testing"
+
+func TestSemVer(t *testing.T) {
+	if SemVer("1.10.0", "1.2.0") {
+		t.Fatalf("asc.TestSemVer failed: %v\n", greaterThanErr)
+	}
+
+	ordered := []string{
+		"1.0",
+		"invalid",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"1.0.0-beta.0a",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+		"99999999999999999999.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			if SemVer(ordered[i], ordered[j]) != (i < j) {
+				t.Fatalf("asc.TestSemVer failed: SemVer(%q, %q) should be %v\n", ordered[i], ordered[j], i < j)
+			}
+		}
+	}
+
+	if SemVer("1.0.0+build.1", "1.0.0+build.2") || SemVer("1.0.0+build.2", "1.0.0+build.1") {
+		t.Fatal("asc.TestSemVer failed: build metadata affected precedence")
+	}
+	for _, invalid := range []string{"1.0.0-", "1.0.0-a..b", "1.0.x", "1.0.0.0", "1.0.0-a_b", "01.2.3", "1.02.3", "1.0.0-01"} {
+		if !SemVer(invalid, "0.0.1") {
+			t.Fatalf("asc.TestSemVer failed: invalid version %q was accepted\n", invalid)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package asc
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// String is a less than comparison function for the string type.
+func String(i, j interface{}) bool {
+	return i.(string) < j.(string)
+}
+
+// foldRune returns the smallest rune that is equivalent to r under simple Unicode case folding.
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// StringFold is a case-insensitive less than comparison function for the string type.
+// Strings that are equal under simple Unicode case folding are treated as equal.
+func StringFold(i, j interface{}) bool {
+	s, t := i.(string), j.(string)
+	for s != "" && t != "" {
+		sr, sSize := utf8.DecodeRuneInString(s)
+		tr, tSize := utf8.DecodeRuneInString(t)
+
+		if sf, tf := foldRune(sr), foldRune(tr); sf != tf {
+			return sf < tf
+		}
+		s, t = s[sSize:], t[tSize:]
+	}
+	return s == "" && t != ""
+}
+
+// Bytes is a less than comparison function for the []byte type.
+func Bytes(i, j interface{}) bool {
+	return bytes.Compare(i.([]byte), j.([]byte)) < 0
+}
+
+// Rune is a less than comparison function for the rune type.
+func Rune(i, j interface{}) bool {
+	return i.(rune) < j.(rune)
+}
@@ -0,0 +1,33 @@
+package asc
+
+import "testing"
+
+func TestString(t *testing.T) {
+	if String("b", "a") {
+		t.Fatalf("asc.TestString failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestStringFold(t *testing.T) {
+	if StringFold("B", "a") {
+		t.Fatalf("asc.TestStringFold failed: %v\n", greaterThanErr)
+	}
+	if StringFold("ABC", "abc") || StringFold("abc", "ABC") {
+		t.Fatal("asc.TestStringFold failed: strings that differ only in case were not equal")
+	}
+	if StringFold("ab", "A") {
+		t.Fatalf("asc.TestStringFold failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	if Bytes([]byte("b"), []byte("a")) {
+		t.Fatalf("asc.TestBytes failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestRune(t *testing.T) {
+	if Rune('b', 'a') {
+		t.Fatalf("asc.TestRune failed: %v\n", greaterThanErr)
+	}
+}
@@ -6,3 +6,8 @@ import "time"
 func Time(i, j interface{}) bool {
 	return i.(time.Time).Before(j.(time.Time))
 }
+
+// Duration is a less than comparison function for the time.Duration type.
+func Duration(i, j interface{}) bool {
+	return i.(time.Duration) < j.(time.Duration)
+}
@@ -13,3 +13,9 @@ func TestTime(t *testing.T) {
 		t.Fatal("asc.TestTime failed: laterDate was before earlierDate")
 	}
 }
+
+func TestDuration(t *testing.T) {
+	if Duration(time.Hour, time.Minute) {
+		t.Fatalf("asc.TestDuration failed: %v\n", greaterThanErr)
+	}
+}
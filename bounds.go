@@ -2,9 +2,17 @@ package sortedmap
 
 import "sort"
 
-func (sm *SortedMap) setBoundIdx(boundVal interface{}) int {
+// lowerBoundIdx returns the index of the first value that is equal to or greater than boundVal.
+func (sm *SortedMap) lowerBoundIdx(boundVal interface{}) int {
 	return sort.Search(len(sm.sorted), func(i int) bool {
-		return sm.lessFn(boundVal, sm.idx[sm.sorted[i]])
+		return sm.cmpFn(sm.idx[sm.sorted[i]], boundVal) >= 0
+	})
+}
+
+// upperBoundIdx returns the index of the first value that is greater than boundVal.
+func (sm *SortedMap) upperBoundIdx(boundVal interface{}) int {
+	return sort.Search(len(sm.sorted), func(i int) bool {
+		return sm.cmpFn(sm.idx[sm.sorted[i]], boundVal) > 0
 	})
 }
 
@@ -15,32 +23,24 @@ func (sm *SortedMap) boundsIdxSearch(lowerBound, upperBound interface{}) []int {
 	}
 
 	if lowerBound != nil && upperBound != nil {
-		if sm.lessFn(upperBound, lowerBound) {
+		if sm.cmpFn(upperBound, lowerBound) < 0 {
 			return nil
 		}
 	}
 
 	lowerBoundIdx := 0
 	if lowerBound != nil {
-		lowerBoundIdx = sm.setBoundIdx(lowerBound)
+		lowerBoundIdx = sm.upperBoundIdx(lowerBound)
 
-		if lowerBoundIdx == smLen {
+		// Values equal to the lower bound are only included when they are the greatest values in the collection.
+		if lowerBoundIdx == smLen && sm.cmpFn(sm.idx[sm.sorted[smLen-1]], lowerBound) == 0 {
 			lowerBoundIdx--
 		}
-		if lowerBoundIdx >= 0 && sm.lessFn(sm.idx[sm.sorted[lowerBoundIdx]], lowerBound) {
-			lowerBoundIdx++
-		}
 	}
 
 	upperBoundIdx := smLen - 1
 	if upperBound != nil {
-		upperBoundIdx = sm.setBoundIdx(upperBound)
-		if upperBoundIdx == smLen {
-			upperBoundIdx--
-		}
-		if upperBoundIdx >= 0 && sm.lessFn(upperBound, sm.idx[sm.sorted[upperBoundIdx]]) {
-			upperBoundIdx--
-		}
+		upperBoundIdx = sm.upperBoundIdx(upperBound) - 1
 	}
 
 	if lowerBoundIdx > upperBoundIdx {
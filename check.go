@@ -0,0 +1,92 @@
+package sortedmap
+
+import (
+	"fmt"
+	"log"
+)
+
+const (
+	irreflexivityErr     = "Comparison function violation: %+v is less than itself."
+	asymmetryErr         = "Comparison function violation: %+v and %+v are each less than the other."
+	transitivityErr      = "Comparison function violation: %+v is less than %+v and %+v is less than %+v, but %+v is not less than %+v."
+	incomparabilityErr   = "Comparison function violation: %+v is equal to %+v and %+v is equal to %+v, but %+v is not equal to %+v."
+	unsortedAfterErr     = "Comparison function violation after %v: the value %+v of key %+v is sorted before the lesser value %+v of key %+v."
+	keyNotFoundSortedErr = "Comparison function violation: key %+v was not found by binary search."
+)
+
+// CheckComparator checks that cmpFn is a strict weak ordering of the given sample values,
+// returning an error that describes the first violation found, or nil.
+// cmpFn is checked for irreflexivity, asymmetry, transitivity and transitivity of incomparability,
+// which requires O(n^3) comparisons, so a small but varied set of samples should be used.
+func CheckComparator(cmpFn ComparisonFunc, samples []interface{}) error {
+	cmpFn = setComparisonFunc(cmpFn)
+
+	equal := func(a, b interface{}) bool {
+		return !cmpFn(a, b) && !cmpFn(b, a)
+	}
+
+	for _, a := range samples {
+		if cmpFn(a, a) {
+			return fmt.Errorf(irreflexivityErr, a)
+		}
+		for _, b := range samples {
+			if cmpFn(a, b) && cmpFn(b, a) {
+				return fmt.Errorf(asymmetryErr, a, b)
+			}
+		}
+	}
+
+	for _, a := range samples {
+		for _, b := range samples {
+			aLessB, aEqualB := cmpFn(a, b), equal(a, b)
+			if !aLessB && !aEqualB {
+				continue
+			}
+			for _, c := range samples {
+				if aLessB && cmpFn(b, c) && !cmpFn(a, c) {
+					return fmt.Errorf(transitivityErr, a, b, b, c, a, c)
+				}
+				if aEqualB && equal(b, c) && !equal(a, c) {
+					return fmt.Errorf(incomparabilityErr, a, b, b, c, a, c)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Debug returns an Option that validates the order of the collection in O(n) time after every Insert, Replace, Delete
+// and BoundedDelete, including their batch variants, and passes any violation of the comparison function's ordering to report.
+// Deletes that cannot find a key because of an inconsistent comparison function fall back to a linear search
+// and are reported, instead of panicking. If report is nil, violations are written using the standard logger.
+func Debug(report func(err error)) Option {
+	if report == nil {
+		report = func(err error) {
+			log.Print(err)
+		}
+	}
+	return func(sm *SortedMap) {
+		sm.debugFn = report
+	}
+}
+
+// checkOrder reports the first pair of adjacent records that are out of order, if debug mode is enabled.
+func (sm *SortedMap) checkOrder(op string) {
+	if sm.debugFn == nil {
+		return
+	}
+	for i := 1; i < len(sm.sorted); i++ {
+		prevKey, key := sm.sorted[i-1], sm.sorted[i]
+		if sm.lessFn(sm.idx[key], sm.idx[prevKey]) {
+			sm.debugFn(fmt.Errorf(unsortedAfterErr, op, sm.idx[prevKey], prevKey, sm.idx[key], key))
+			return
+		}
+	}
+}
+
+func (sm *SortedMap) reportKeyNotFound(key interface{}) {
+	if sm.debugFn != nil {
+		sm.debugFn(fmt.Errorf(keyNotFoundSortedErr, key))
+	}
+}
@@ -0,0 +1,76 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func TestCheckComparator(t *testing.T) {
+	samples := []interface{}{3, 1, 2, 2, -5, 0}
+
+	if err := CheckComparator(asc.Int, samples); err != nil {
+		t.Fatalf("TestCheckComparator failed: %v", err)
+	}
+
+	badFns := []ComparisonFunc{
+		// Not irreflexive.
+		func(i, j interface{}) bool {
+			return i.(int) <= j.(int)
+		},
+		// Not transitive.
+		func(i, j interface{}) bool {
+			return (i.(int)+3)%5 < (j.(int)+3)%5 || (j.(int)-i.(int)) == 1
+		},
+		// Incomparability is not transitive.
+		func(i, j interface{}) bool {
+			return j.(int)-i.(int) > 1
+		},
+	}
+	for n, cmpFn := range badFns {
+		if err := CheckComparator(cmpFn, samples); err == nil {
+			t.Fatalf("TestCheckComparator failed: no violation was found for comparison function %v.", n)
+		}
+	}
+}
+
+func TestDebug(t *testing.T) {
+	var violations []error
+	report := func(err error) {
+		violations = append(violations, err)
+	}
+
+	sm := New(0, asc.Int, Debug(report))
+	for i := 0; i < 10; i++ {
+		sm.Insert(i, i)
+	}
+	sm.Replace(3, 30)
+	sm.Delete(4)
+	if err := sm.BoundedDelete(0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("TestDebug failed: unexpected violations: %v", violations)
+	}
+
+	// Changing the order of the values behind the collection's back makes the comparison function inconsistent with it.
+	sm.SetComparisonFunc(func(i, j interface{}) bool {
+		return i.(int)%4 < j.(int)%4
+	})
+	sm.lessFn = asc.Int
+	sm.Insert(100, 6)
+	if len(violations) == 0 {
+		t.Fatal("TestDebug failed: an unsorted collection was not reported.")
+	}
+
+	violations = nil
+	keys := append([]interface{}{}, sm.Keys()...)
+	for _, key := range keys {
+		if !sm.Delete(key) {
+			t.Fatalf("TestDebug failed: key %v was not deleted.", key)
+		}
+	}
+	if sm.Len() != 0 || len(violations) == 0 {
+		t.Fatalf("TestDebug failed: expected an empty collection and violations, got %v records and %v violations.", sm.Len(), len(violations))
+	}
+}
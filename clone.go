@@ -0,0 +1,69 @@
+package sortedmap
+
+// emptyCopy returns an empty collection with the same comparison function, options, codecs and JSON decoders as sm.
+func (sm *SortedMap) emptyCopy() *SortedMap {
+	cp := &SortedMap{
+		idx:           make(map[interface{}]interface{}),
+		lessFn:        sm.lessFn,
+		cmpFn:         sm.cmpFn,
+		jsonKeyFn:     sm.jsonKeyFn,
+		jsonValFn:     sm.jsonValFn,
+		keyCodec:      sm.keyCodec,
+		valCodec:      sm.valCodec,
+		cmpName:       sm.cmpName,
+		rejectNaN:     sm.rejectNaN,
+		uniqueVals:    sm.uniqueVals,
+		debugFn:       sm.debugFn,
+		interpolateFn: sm.interpolateFn,
+	}
+	if sm.agg != nil {
+		cp.agg = newAggTree(sm.agg.agg)
+	}
+	return cp
+}
+
+// Clone returns a copy of the collection in O(n) time, without sorting.
+// The comparison functions, options, indexes, codecs and JSON decoders are copied, while an attached WAL is not.
+func (sm *SortedMap) Clone() *SortedMap {
+	idx := make(map[interface{}]interface{}, len(sm.idx))
+	for key, val := range sm.idx {
+		idx[key] = val
+	}
+	sorted := make([]interface{}, len(sm.sorted), cap(sm.sorted))
+	copy(sorted, sm.sorted)
+
+	clone := sm.emptyCopy()
+	clone.setRecords(idx, sorted)
+
+	if sm.keyLessFn != nil {
+		clone.keyLessFn = sm.keyLessFn
+		clone.keySorted = make([]interface{}, len(sm.keySorted), cap(sm.keySorted))
+		copy(clone.keySorted, sm.keySorted)
+	}
+
+	for name, ix := range sm.indexes {
+		if clone.indexes == nil {
+			clone.indexes = make(map[string]*Index, len(sm.indexes))
+		}
+		ixSorted := make([]interface{}, len(ix.sorted), cap(ix.sorted))
+		copy(ixSorted, ix.sorted)
+
+		clone.indexes[name] = &Index{
+			name:   name,
+			sorted: ixSorted,
+			lessFn: ix.lessFn,
+			sm:     clone,
+		}
+	}
+
+	return clone
+}
+
+// CloneWith returns a copy of the collection that is sorted using the given comparison function.
+// Records with equal values keep their existing order.
+func (sm *SortedMap) CloneWith(cmpFn ComparisonFunc) *SortedMap {
+	clone := sm.Clone()
+	clone.SetComparisonFunc(cmpFn)
+
+	return clone
+}
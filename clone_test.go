@@ -0,0 +1,71 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func TestClone(t *testing.T) {
+	sm, records, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := sm.Clone()
+	if err := verifyEqualMaps(sm, clone); err != nil {
+		t.Fatalf("TestClone failed: %v", err)
+	}
+
+	clone.Delete(records[0].Key)
+	clone.Replace(records[1].Key, maxTime)
+	if !sm.Has(records[0].Key) || sm.idx[records[1].Key] != records[1].Val {
+		t.Fatal("TestClone failed: changing the clone changed the original.")
+	}
+
+	iterCh, err := clone.IterCh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iterCh.Close()
+
+	if err := verifyRecords(iterCh.Records(), false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCloneWith(t *testing.T) {
+	sm, _, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := sm.CloneWith(desc.Time)
+	if clone.Len() != sm.Len() {
+		t.Fatalf("TestCloneWith failed: Expected: %v records, Had: %v.", sm.Len(), clone.Len())
+	}
+
+	iterCh, err := clone.IterCh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iterCh.Close()
+
+	if err := verifyRecords(iterCh.Records(), true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCloneWithStableOrder(t *testing.T) {
+	sm := New(3, asc.Int)
+	sm.Insert("a", 1)
+	sm.Insert("b", 1)
+	sm.Insert("c", 2)
+
+	if err := verifyRecs(sm.CloneWith(desc.Int), []Record{
+		{"c", 2}, {"a", 1}, {"b", 1},
+	}); err != nil {
+		t.Fatalf("TestCloneWithStableOrder failed: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+// Package cmpx provides functions for building SortedMap comparison functions from smaller ones,
+// so that ordering values by multiple fields can be declared instead of hand-written.
+//
+//	cmpFn := cmpx.By(func(v interface{}) interface{} {
+//		return v.(Task).Priority
+//	}, desc.Int).ThenBy(func(v interface{}) interface{} {
+//		return v.(Task).Created
+//	}, asc.Time)
+//
+//	sm := sortedmap.New(0, cmpFn)
+package cmpx
+
+import (
+	"reflect"
+
+	"github.com/umpc/go-sortedmap"
+)
+
+// By returns a comparison function that applies cmpFn to the part of each value selected by extract.
+// The returned function's Then and ThenBy methods can be used to add tie-breaking comparisons.
+func By(extract sortedmap.ExtractFunc, cmpFn sortedmap.ComparisonFunc) sortedmap.ComparisonFunc {
+	return func(i, j interface{}) bool {
+		return cmpFn(extract(i), extract(j))
+	}
+}
+
+// Then returns a comparison function that orders values using each given function in turn,
+// moving on to the next function only for values that the previous functions consider equal.
+func Then(cmpFns ...sortedmap.ComparisonFunc) sortedmap.ComparisonFunc {
+	return func(i, j interface{}) bool {
+		for _, cmpFn := range cmpFns {
+			if cmpFn(i, j) {
+				return true
+			}
+			if cmpFn(j, i) {
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// Reverse returns a comparison function that orders values in the opposite order of cmpFn.
+func Reverse(cmpFn sortedmap.ComparisonFunc) sortedmap.ComparisonFunc {
+	return func(i, j interface{}) bool {
+		return cmpFn(j, i)
+	}
+}
+
+// isNil checks if v is nil or is a nil pointer, map, slice, channel, function or interface.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// NilsFirst returns a comparison function that orders nil values before all other values,
+// and uses cmpFn for values that are not nil.
+func NilsFirst(cmpFn sortedmap.ComparisonFunc) sortedmap.ComparisonFunc {
+	return func(i, j interface{}) bool {
+		iNil, jNil := isNil(i), isNil(j)
+		if iNil || jNil {
+			return iNil && !jNil
+		}
+		return cmpFn(i, j)
+	}
+}
+
+// NilsLast returns a comparison function that orders nil values after all other values,
+// and uses cmpFn for values that are not nil.
+func NilsLast(cmpFn sortedmap.ComparisonFunc) sortedmap.ComparisonFunc {
+	return func(i, j interface{}) bool {
+		iNil, jNil := isNil(i), isNil(j)
+		if iNil || jNil {
+			return !iNil && jNil
+		}
+		return cmpFn(i, j)
+	}
+}
@@ -0,0 +1,125 @@
+package cmpx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap"
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+type task struct {
+	Priority int
+	Created  time.Time
+}
+
+func priority(v interface{}) interface{} {
+	return v.(task).Priority
+}
+
+func created(v interface{}) interface{} {
+	return v.(task).Created
+}
+
+var (
+	earlierDate = time.Date(2017, 06, 05, 18, 0, 0, 0, time.UTC)
+	laterDate   = time.Date(2018, 07, 06, 21, 0, 0, 0, time.UTC)
+)
+
+func TestBy(t *testing.T) {
+	cmpFn := By(priority, asc.Int)
+
+	if cmpFn(task{Priority: 1}, task{Priority: 0}) {
+		t.Fatalf("cmpx.TestBy failed: %v\n", greaterThanErr)
+	}
+	if !cmpFn(task{Priority: 0}, task{Priority: 1}) {
+		t.Fatalf("cmpx.TestBy failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestThenBy(t *testing.T) {
+	cmpFn := By(priority, desc.Int).ThenBy(created, asc.Time)
+
+	if cmpFn(task{Priority: 0, Created: earlierDate}, task{Priority: 1, Created: laterDate}) {
+		t.Fatalf("cmpx.TestThenBy failed: %v\n", greaterThanErr)
+	}
+	if cmpFn(task{Priority: 1, Created: laterDate}, task{Priority: 1, Created: earlierDate}) {
+		t.Fatalf("cmpx.TestThenBy failed: %v\n", greaterThanErr)
+	}
+	if !cmpFn(task{Priority: 1, Created: earlierDate}, task{Priority: 1, Created: laterDate}) {
+		t.Fatalf("cmpx.TestThenBy failed: %v\n", greaterThanErr)
+	}
+	if cmpFn(task{Priority: 1, Created: earlierDate}, task{Priority: 1, Created: earlierDate}) {
+		t.Fatalf("cmpx.TestThenBy failed: %v\n", equalErr)
+	}
+}
+
+func TestThen(t *testing.T) {
+	cmpFn := Then(By(priority, desc.Int), By(created, asc.Time))
+
+	if cmpFn(task{Priority: 0, Created: earlierDate}, task{Priority: 1, Created: laterDate}) {
+		t.Fatalf("cmpx.TestThen failed: %v\n", greaterThanErr)
+	}
+	if cmpFn(task{Priority: 1, Created: laterDate}, task{Priority: 1, Created: earlierDate}) {
+		t.Fatalf("cmpx.TestThen failed: %v\n", greaterThanErr)
+	}
+	if cmpFn(task{Priority: 1, Created: earlierDate}, task{Priority: 1, Created: earlierDate}) {
+		t.Fatalf("cmpx.TestThen failed: %v\n", equalErr)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	if Reverse(asc.Int)(0, 1) {
+		t.Fatalf("cmpx.TestReverse failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestNilsFirst(t *testing.T) {
+	one := 1
+	cmpFn := NilsFirst(func(i, j interface{}) bool {
+		return *i.(*int) < *j.(*int)
+	})
+
+	if cmpFn(&one, nil) || cmpFn(&one, (*int)(nil)) {
+		t.Fatalf("cmpx.TestNilsFirst failed: %v\n", greaterThanErr)
+	}
+	if !cmpFn(nil, &one) {
+		t.Fatalf("cmpx.TestNilsFirst failed: %v\n", greaterThanErr)
+	}
+	if cmpFn(nil, (*int)(nil)) {
+		t.Fatalf("cmpx.TestNilsFirst failed: %v\n", equalErr)
+	}
+}
+
+func TestNilsLast(t *testing.T) {
+	one := 1
+	cmpFn := NilsLast(func(i, j interface{}) bool {
+		return *i.(*int) < *j.(*int)
+	})
+
+	if cmpFn(nil, &one) || cmpFn((*int)(nil), &one) {
+		t.Fatalf("cmpx.TestNilsLast failed: %v\n", greaterThanErr)
+	}
+	if !cmpFn(&one, nil) {
+		t.Fatalf("cmpx.TestNilsLast failed: %v\n", greaterThanErr)
+	}
+	if cmpFn(nil, (*int)(nil)) {
+		t.Fatalf("cmpx.TestNilsLast failed: %v\n", equalErr)
+	}
+}
+
+func TestWithSortedMap(t *testing.T) {
+	sm := sortedmap.New(4, By(priority, desc.Int).ThenBy(created, asc.Time))
+	sm.Insert("a", task{Priority: 1, Created: laterDate})
+	sm.Insert("b", task{Priority: 2, Created: laterDate})
+	sm.Insert("c", task{Priority: 1, Created: earlierDate})
+	sm.Insert("d", task{Priority: 0, Created: earlierDate})
+
+	expected := []interface{}{"b", "c", "a", "d"}
+	for i, key := range sm.Keys() {
+		if key != expected[i] {
+			t.Fatalf("cmpx.TestWithSortedMap failed: Expected: %v, Had: %v.", expected, sm.Keys())
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package cmpx
+
+const (
+	greaterThanErr = "the higher value was less than the lesser value!"
+	equalErr       = "equal values were not treated as equal!"
+)
@@ -0,0 +1,273 @@
+package cmpx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/umpc/go-sortedmap"
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+// TagName is the struct tag key read by FromTags.
+const TagName = "sortedmap"
+
+const (
+	notStructErr        = "Unsupported type, expected a struct or a pointer to a struct: %v"
+	noTaggedFieldsErr   = "No fields are tagged in struct type: %v"
+	invalidTagErr       = "Invalid tag on field %v: %v"
+	fieldNotFoundErr    = "Field not found: %v"
+	unexportedFieldErr  = "Unexported field: %v"
+	unsupportedFieldErr = "Unsupported type for field %v: %v"
+	emptyFieldPathErr   = "The field path is empty."
+	pointerFieldErr     = "Field path steps through a pointer, which may be nil: %v"
+)
+
+type fieldCmp struct {
+	asc, desc sortedmap.ComparisonFunc
+	extract   func(reflect.Value) interface{}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	bigIntType     = reflect.TypeOf((*big.Int)(nil))
+	bigFloatType   = reflect.TypeOf((*big.Float)(nil))
+	bigRatType     = reflect.TypeOf((*big.Rat)(nil))
+	addrType       = reflect.TypeOf(netip.Addr{})
+	prefixType     = reflect.TypeOf(netip.Prefix{})
+	fieldInterface = func(rv reflect.Value) interface{} { return rv.Interface() }
+)
+
+// fieldCmpFor selects the asc and desc functions used to compare fields of type t.
+// Fields with a basic kind are converted to the widest type of that kind,
+// so that named types such as time.Duration are supported.
+func fieldCmpFor(t reflect.Type) (fieldCmp, bool) {
+	switch t {
+	case timeType:
+		return fieldCmp{asc.Time, desc.Time, fieldInterface}, true
+	case bigIntType:
+		return fieldCmp{asc.BigInt, desc.BigInt, fieldInterface}, true
+	case bigFloatType:
+		return fieldCmp{asc.BigFloat, desc.BigFloat, fieldInterface}, true
+	case bigRatType:
+		return fieldCmp{asc.BigRat, desc.BigRat, fieldInterface}, true
+	case addrType:
+		return fieldCmp{asc.Addr, desc.Addr, fieldInterface}, true
+	case prefixType:
+		return fieldCmp{asc.Prefix, desc.Prefix, fieldInterface}, true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fieldCmp{asc.Int64, desc.Int64, func(rv reflect.Value) interface{} { return rv.Int() }}, true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return fieldCmp{asc.Uint64, desc.Uint64, func(rv reflect.Value) interface{} { return rv.Uint() }}, true
+
+	case reflect.Float32, reflect.Float64:
+		return fieldCmp{asc.Float64Total, desc.Float64Total, func(rv reflect.Value) interface{} { return rv.Float() }}, true
+
+	case reflect.Complex64, reflect.Complex128:
+		return fieldCmp{asc.Complex128, desc.Complex128, func(rv reflect.Value) interface{} { return rv.Complex() }}, true
+
+	case reflect.String:
+		return fieldCmp{asc.String, desc.String, func(rv reflect.Value) interface{} { return rv.String() }}, true
+
+	case reflect.Bool:
+		return fieldCmp{asc.Bool, desc.Bool, func(rv reflect.Value) interface{} { return rv.Bool() }}, true
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fieldCmp{asc.Bytes, desc.Bytes, func(rv reflect.Value) interface{} { return rv.Bytes() }}, true
+		}
+	}
+
+	return fieldCmp{}, false
+}
+
+func structType(sample interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(sample)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf(notStructErr, t)
+	}
+	return t, nil
+}
+
+// byField returns a comparison function for the field at index, accepting both struct values and pointers to structs.
+func byField(index []int, fc fieldCmp, descending bool) sortedmap.ComparisonFunc {
+	cmpFn := fc.asc
+	if descending {
+		cmpFn = fc.desc
+	}
+
+	extract := func(v interface{}) interface{} {
+		return fc.extract(reflect.Indirect(reflect.ValueOf(v)).FieldByIndex(index))
+	}
+	return By(extract, cmpFn)
+}
+
+// checkFieldIndex returns an error if reaching the field at index from t steps through a pointer,
+// such as an embedded *struct, because the pointer may be nil when values are compared.
+func checkFieldIndex(t reflect.Type, index []int, path string) error {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Ptr {
+			return fmt.Errorf(pointerFieldErr, path)
+		}
+	}
+	return nil
+}
+
+func fieldCmpForField(name string, f reflect.StructField) (fieldCmp, error) {
+	if f.PkgPath != "" {
+		return fieldCmp{}, fmt.Errorf(unexportedFieldErr, name)
+	}
+	fc, ok := fieldCmpFor(f.Type)
+	if !ok {
+		return fieldCmp{}, fmt.Errorf(unsupportedFieldErr, name, f.Type)
+	}
+	return fc, nil
+}
+
+// FromField returns a comparison function that orders struct values, or pointers to struct values,
+// by the field selected by path, such as "Meta.CreatedAt".
+// The type of sample is used to resolve the path, and an error is returned if a field in the path
+// is not found, is unexported, is reached through a pointer, or has a type that is not supported by the asc and desc packages.
+func FromField(sample interface{}, path string, descending bool) (sortedmap.ComparisonFunc, error) {
+	t, err := structType(sample)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, errors.New(emptyFieldPathErr)
+	}
+
+	var (
+		index []int
+		f     reflect.StructField
+	)
+	for n, name := range strings.Split(path, ".") {
+		if n > 0 {
+			if f.Type.Kind() == reflect.Ptr {
+				return nil, fmt.Errorf(pointerFieldErr, path)
+			}
+			if f.Type.Kind() != reflect.Struct {
+				return nil, fmt.Errorf(unsupportedFieldErr, f.Name, f.Type)
+			}
+			t = f.Type
+		}
+
+		var ok bool
+		if f, ok = t.FieldByName(name); !ok {
+			return nil, fmt.Errorf(fieldNotFoundErr, path)
+		}
+		if f.PkgPath != "" {
+			return nil, fmt.Errorf(unexportedFieldErr, path)
+		}
+		if err := checkFieldIndex(t, f.Index, path); err != nil {
+			return nil, err
+		}
+		index = append(index, f.Index...)
+	}
+
+	fc, err := fieldCmpForField(path, f)
+	if err != nil {
+		return nil, err
+	}
+	return byField(index, fc, descending), nil
+}
+
+type taggedField struct {
+	order      int
+	index      []int
+	descending bool
+	fc         fieldCmp
+}
+
+func parseTag(name, tag string) (taggedField, error) {
+	var tf taggedField
+
+	for _, opt := range strings.Split(tag, ",") {
+		switch opt = strings.TrimSpace(opt); {
+		case opt == "asc":
+			tf.descending = false
+
+		case opt == "desc":
+			tf.descending = true
+
+		case strings.HasPrefix(opt, "order="):
+			order, err := strconv.Atoi(strings.TrimPrefix(opt, "order="))
+			if err != nil {
+				return tf, fmt.Errorf(invalidTagErr, name, tag)
+			}
+			tf.order = order
+
+		default:
+			return tf, fmt.Errorf(invalidTagErr, name, tag)
+		}
+	}
+	return tf, nil
+}
+
+// FromTags returns a comparison function that orders struct values, or pointers to struct values,
+// using the fields of sample's type that have a "sortedmap" tag, such as:
+//
+//	Priority int       `sortedmap:"order=1,desc"`
+//	Created  time.Time `sortedmap:"order=2"`
+//
+// Fields are compared by ascending order number, and fields with equal or missing order numbers are compared
+// in declaration order. Values are sorted in ascending order unless the "desc" option is given.
+// An error is returned if a tag is invalid, or a tagged field is unexported or has a type that is not supported
+// by the asc and desc packages.
+func FromTags(sample interface{}) (sortedmap.ComparisonFunc, error) {
+	t, err := structType(sample)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []taggedField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup(TagName)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		tf, err := parseTag(f.Name, tag)
+		if err != nil {
+			return nil, err
+		}
+		if tf.fc, err = fieldCmpForField(f.Name, f); err != nil {
+			return nil, err
+		}
+		tf.index = f.Index
+
+		fields = append(fields, tf)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf(noTaggedFieldsErr, t)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].order < fields[j].order
+	})
+
+	cmpFns := make([]sortedmap.ComparisonFunc, len(fields))
+	for i, tf := range fields {
+		cmpFns[i] = byField(tf.index, tf.fc, tf.descending)
+	}
+	if len(cmpFns) == 1 {
+		return cmpFns[0], nil
+	}
+	return Then(cmpFns...), nil
+}
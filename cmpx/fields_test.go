@@ -0,0 +1,103 @@
+package cmpx
+
+import (
+	"testing"
+	"time"
+)
+
+type meta struct {
+	CreatedAt time.Time
+	Owner     string
+}
+
+type pointerJob struct {
+	*meta
+	M *meta
+}
+
+type job struct {
+	Name     string        `sortedmap:"order=3"`
+	Priority int8          `sortedmap:"order=1,desc"`
+	Timeout  time.Duration `sortedmap:"order=2"`
+	Meta     meta
+	Ignored  []int `sortedmap:"-"`
+}
+
+func TestFromTags(t *testing.T) {
+	cmpFn, err := FromTags(job{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ordered := []*job{
+		{Priority: 2, Timeout: time.Minute, Name: "b"},
+		{Priority: 1, Timeout: time.Second, Name: "b"},
+		{Priority: 1, Timeout: time.Minute, Name: "a"},
+		{Priority: 1, Timeout: time.Minute, Name: "b"},
+	}
+	for i := range ordered {
+		for j := range ordered {
+			if cmpFn(ordered[i], ordered[j]) != (i < j) {
+				t.Fatalf("cmpx.TestFromTags failed: %v, %+v and %+v\n", greaterThanErr, ordered[i], ordered[j])
+			}
+			if cmpFn(*ordered[i], *ordered[j]) != (i < j) {
+				t.Fatalf("cmpx.TestFromTags failed: %v, %+v and %+v\n", greaterThanErr, ordered[i], ordered[j])
+			}
+		}
+	}
+}
+
+func TestFromTagsErrors(t *testing.T) {
+	samples := []interface{}{
+		nil,
+		0,
+		meta{},
+		struct {
+			A int `sortedmap:"order=x"`
+		}{},
+		struct {
+			A int `sortedmap:"up"`
+		}{},
+		struct {
+			a int `sortedmap:"order=1"`
+		}{},
+		struct {
+			A []int `sortedmap:"order=1"`
+		}{},
+	}
+	for _, sample := range samples {
+		if _, err := FromTags(sample); err == nil {
+			t.Fatalf("cmpx.TestFromTagsErrors failed: no error was returned for %#v\n", sample)
+		}
+	}
+}
+
+func TestFromField(t *testing.T) {
+	cmpFn, err := FromField(&job{}, "Meta.CreatedAt", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	earlier, later := job{Meta: meta{CreatedAt: earlierDate}}, job{Meta: meta{CreatedAt: laterDate}}
+
+	if !cmpFn(earlier, later) || cmpFn(later, earlier) || cmpFn(earlier, earlier) {
+		t.Fatalf("cmpx.TestFromField failed: %v\n", greaterThanErr)
+	}
+
+	if cmpFn, err = FromField(job{}, "Meta.CreatedAt", true); err != nil {
+		t.Fatal(err)
+	}
+	if cmpFn(earlier, later) || !cmpFn(later, earlier) {
+		t.Fatalf("cmpx.TestFromField failed: %v\n", greaterThanErr)
+	}
+
+	for _, path := range []string{"", "Missing", "Meta.Missing", "Name.Length", "Meta.CreatedAt.wall", "Ignored"} {
+		if _, err := FromField(job{}, path, false); err == nil {
+			t.Fatalf("cmpx.TestFromField failed: no error was returned for path %q\n", path)
+		}
+	}
+	for _, path := range []string{"CreatedAt", "M.CreatedAt"} {
+		if _, err := FromField(pointerJob{}, path, false); err == nil {
+			t.Fatalf("cmpx.TestFromField failed: no error was returned for a path through a pointer %q\n", path)
+		}
+	}
+}
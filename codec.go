@@ -0,0 +1,167 @@
+package sortedmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"time"
+)
+
+// EncodeFunc defines the type of function used to convert a key or value into bytes.
+type EncodeFunc func(v interface{}) ([]byte, error)
+
+// Codec contains the functions used to convert keys or values to and from bytes
+// when writing and reading binary snapshots.
+type Codec struct {
+	Encode EncodeFunc
+	Decode DecodeFunc
+}
+
+// Codecs for common key and value types.
+// GobCodec supports any type that has been registered using gob.Register and is used when a codec is not set.
+var (
+	StringCodec  = Codec{Encode: encodeString, Decode: decodeString}
+	BytesCodec   = Codec{Encode: encodeBytes, Decode: decodeBytes}
+	IntCodec     = Codec{Encode: encodeInt, Decode: decodeInt}
+	Int64Codec   = Codec{Encode: encodeInt64, Decode: decodeInt64}
+	Uint64Codec  = Codec{Encode: encodeUint64, Decode: decodeUint64}
+	Float64Codec = Codec{Encode: encodeFloat64, Decode: decodeFloat64}
+	TimeCodec    = Codec{Encode: encodeTime, Decode: decodeTime}
+	GobCodec     = Codec{Encode: encodeGob, Decode: decodeGob}
+)
+
+const invalidLengthErr = "Invalid encoded length for %v: %v bytes."
+
+func setCodec(c Codec) Codec {
+	if c.Encode == nil || c.Decode == nil {
+		return GobCodec
+	}
+	return c
+}
+
+func typeErr(v interface{}, expected string) error {
+	return fmt.Errorf("Unsupported type for %v codec: %T", expected, v)
+}
+
+func encodeString(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, typeErr(v, "string")
+	}
+	return []byte(s), nil
+}
+
+func decodeString(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+func encodeBytes(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, typeErr(v, "[]byte")
+	}
+	return b, nil
+}
+
+func decodeBytes(data []byte) (interface{}, error) {
+	return append([]byte(nil), data...), nil
+}
+
+func encodeInt(v interface{}) ([]byte, error) {
+	i, ok := v.(int)
+	if !ok {
+		return nil, typeErr(v, "int")
+	}
+	return encodeInt64(int64(i))
+}
+
+func decodeInt(data []byte) (interface{}, error) {
+	i, err := decodeInt64(data)
+	if err != nil {
+		return nil, err
+	}
+	return int(i.(int64)), nil
+}
+
+func encodeInt64(v interface{}) ([]byte, error) {
+	i, ok := v.(int64)
+	if !ok {
+		return nil, typeErr(v, "int64")
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutVarint(buf, i)], nil
+}
+
+func decodeInt64(data []byte) (interface{}, error) {
+	i, n := binary.Varint(data)
+	if n <= 0 || n != len(data) {
+		return nil, fmt.Errorf(invalidLengthErr, "int64", len(data))
+	}
+	return i, nil
+}
+
+func encodeUint64(v interface{}) ([]byte, error) {
+	u, ok := v.(uint64)
+	if !ok {
+		return nil, typeErr(v, "uint64")
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, u)], nil
+}
+
+func decodeUint64(data []byte) (interface{}, error) {
+	u, n := binary.Uvarint(data)
+	if n <= 0 || n != len(data) {
+		return nil, fmt.Errorf(invalidLengthErr, "uint64", len(data))
+	}
+	return u, nil
+}
+
+func encodeFloat64(v interface{}) ([]byte, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, typeErr(v, "float64")
+	}
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, math.Float64bits(f))
+	return buf, nil
+}
+
+func decodeFloat64(data []byte) (interface{}, error) {
+	if len(data) != 8 {
+		return nil, fmt.Errorf(invalidLengthErr, "float64", len(data))
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+}
+
+func encodeTime(v interface{}) ([]byte, error) {
+	t, ok := v.(time.Time)
+	if !ok {
+		return nil, typeErr(v, "time.Time")
+	}
+	return t.MarshalBinary()
+}
+
+func decodeTime(data []byte) (interface{}, error) {
+	t := time.Time{}
+	err := t.UnmarshalBinary(data)
+	return t, err
+}
+
+func encodeGob(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(&v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeGob(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
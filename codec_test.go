@@ -0,0 +1,66 @@
+package sortedmap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCodecs(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec Codec
+		val   interface{}
+	}{
+		{"StringCodec", StringCodec, "value"},
+		{"IntCodec", IntCodec, -42},
+		{"Int64Codec", Int64Codec, int64(-1 << 40)},
+		{"Uint64Codec", Uint64Codec, uint64(1 << 63)},
+		{"Float64Codec", Float64Codec, 3.25},
+		{"TimeCodec", TimeCodec, time.Date(2017, 6, 5, 18, 0, 0, 0, time.UTC)},
+		{"GobCodec", GobCodec, time.Date(2017, 6, 5, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		data, err := test.codec.Encode(test.val)
+		if err != nil {
+			t.Fatalf("TestCodecs failed: %v: %v", test.name, err)
+		}
+		val, err := test.codec.Decode(data)
+		if err != nil {
+			t.Fatalf("TestCodecs failed: %v: %v", test.name, err)
+		}
+		if val != test.val {
+			t.Fatalf("TestCodecs failed: %v: Expected: %+v, Had: %+v.", test.name, test.val, val)
+		}
+	}
+}
+
+func TestBytesCodec(t *testing.T) {
+	data, err := BytesCodec.Encode([]byte("value"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, err := BytesCodec.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(val.([]byte)) != "value" {
+		t.Fatalf("TestBytesCodec failed: Expected: value, Had: %s.", val)
+	}
+}
+
+func TestCodecUnsupportedTypes(t *testing.T) {
+	for _, c := range []Codec{StringCodec, BytesCodec, IntCodec, Int64Codec, Uint64Codec, Float64Codec, TimeCodec} {
+		if _, err := c.Encode(struct{}{}); err == nil {
+			t.Fatal("TestCodecUnsupportedTypes failed: an unsupported type was encoded.")
+		}
+	}
+}
+
+func TestCodecInvalidData(t *testing.T) {
+	for _, c := range []Codec{IntCodec, Int64Codec, Uint64Codec, Float64Codec, TimeCodec, GobCodec} {
+		if _, err := c.Decode([]byte{0xff}); err == nil {
+			t.Fatal("TestCodecInvalidData failed: invalid data was decoded.")
+		}
+	}
+}
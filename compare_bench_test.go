@@ -0,0 +1,58 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func newBenchMaps(n int) (*SortedMap, *SortedMap, []Record) {
+	records := randRecords(n)
+
+	lessSM := New(n, asc.Time)
+	lessSM.BatchReplace(records)
+	cmpSM := NewWithCompare(n, compareTime)
+	cmpSM.BatchReplace(records)
+
+	return lessSM, cmpSM, records
+}
+
+func boundedKeysNRecords(b *testing.B, sm *SortedMap, records []Record) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lower, upper := records[i%len(records)].Val, records[(i+1)%len(records)].Val
+		if asc.Time(upper, lower) {
+			lower, upper = upper, lower
+		}
+		sm.BoundedKeys(lower, upper)
+	}
+}
+
+func deleteAndReinsertNRecords(b *testing.B, sm *SortedMap, records []Record) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rec := records[i%len(records)]
+		sm.Delete(rec.Key)
+		sm.Insert(rec.Key, rec.Val)
+	}
+}
+
+func BenchmarkBoundedKeysLess10000Records(b *testing.B) {
+	lessSM, _, records := newBenchMaps(10000)
+	boundedKeysNRecords(b, lessSM, records)
+}
+
+func BenchmarkBoundedKeysCompare10000Records(b *testing.B) {
+	_, cmpSM, records := newBenchMaps(10000)
+	boundedKeysNRecords(b, cmpSM, records)
+}
+
+func BenchmarkDeleteInsertLess10000Records(b *testing.B) {
+	lessSM, _, records := newBenchMaps(10000)
+	deleteAndReinsertNRecords(b, lessSM, records)
+}
+
+func BenchmarkDeleteInsertCompare10000Records(b *testing.B) {
+	_, cmpSM, records := newBenchMaps(10000)
+	deleteAndReinsertNRecords(b, cmpSM, records)
+}
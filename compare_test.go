@@ -0,0 +1,124 @@
+package sortedmap
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func compareTime(i, j interface{}) int {
+	return i.(time.Time).Compare(j.(time.Time))
+}
+
+func TestNewWithCompare(t *testing.T) {
+	records := randRecords(1000)
+
+	lessSM := New(0, asc.Time)
+	lessSM.BatchReplace(records)
+	cmpSM := NewWithCompare(0, compareTime)
+	cmpSM.BatchReplace(records)
+
+	if err := verifyEqualMaps(lessSM, cmpSM); err != nil {
+		t.Fatalf("TestNewWithCompare failed: %v", err)
+	}
+
+	iterCh, err := cmpSM.IterCh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyRecords(iterCh.Records(), false); err != nil {
+		t.Fatal(err)
+	}
+	iterCh.Close()
+
+	for _, bounds := range [][]interface{}{
+		{records[0].Val, records[1].Val},
+		{records[2].Val, nil},
+		{nil, records[3].Val},
+	} {
+		lessKeys, lessErr := lessSM.BoundedKeys(bounds[0], bounds[1])
+		cmpKeys, cmpErr := cmpSM.BoundedKeys(bounds[0], bounds[1])
+		if (lessErr == nil) != (cmpErr == nil) || len(lessKeys) != len(cmpKeys) {
+			t.Fatalf("TestNewWithCompare failed: bounds %v returned %v keys, expected %v.", bounds, len(cmpKeys), len(lessKeys))
+		}
+	}
+
+	for _, rec := range records[:500] {
+		lessSM.Delete(rec.Key)
+		if !cmpSM.Delete(rec.Key) {
+			t.Fatalf("TestNewWithCompare failed: key %v was not deleted.", rec.Key)
+		}
+	}
+	if err := verifyEqualMaps(lessSM, cmpSM); err != nil {
+		t.Fatalf("TestNewWithCompare failed: %v", err)
+	}
+}
+
+func TestNewWithCompareSnapshot(t *testing.T) {
+	sm := NewWithCompare(0, compareTime)
+	sm.BatchReplace(randRecords(100))
+
+	buf := new(bytes.Buffer)
+	if _, err := sm.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := buf.Bytes()
+
+	if _, err := New(0, desc.Time).ReadFrom(bytes.NewReader(snapshot)); err == nil {
+		t.Fatal("TestNewWithCompareSnapshot failed: a different order was accepted.")
+	}
+	if _, err := New(0, asc.Time).ReadFrom(bytes.NewReader(snapshot)); err != nil {
+		t.Fatalf("TestNewWithCompareSnapshot failed: an equivalent comparison function was rejected: %v", err)
+	}
+
+	decoded := NewWithCompare(0, compareTime)
+	if _, err := decoded.ReadFrom(bytes.NewReader(snapshot)); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(sm, decoded); err != nil {
+		t.Fatalf("TestNewWithCompareSnapshot failed: %v", err)
+	}
+}
+
+func TestSetCompareFunc(t *testing.T) {
+	sm, _, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sm.SetCompareFunc(func(i, j interface{}) int {
+		return compareTime(j, i)
+	})
+	iterCh, err := sm.IterCh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iterCh.Close()
+
+	if err := verifyRecords(iterCh.Records(), true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewWithCompareSetOps(t *testing.T) {
+	a := NewWithCompare(0, compareTime)
+	a.BatchReplace(randRecords(100))
+
+	for _, sm := range []*SortedMap{Union(a, a, nil), Intersect(a, a, nil), Difference(a, New(0, asc.Time))} {
+		buf := new(bytes.Buffer)
+		if _, err := sm.WriteTo(buf); err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := NewWithCompare(0, compareTime)
+		if _, err := decoded.ReadFrom(buf); err != nil {
+			t.Fatalf("TestNewWithCompareSetOps failed: %v", err)
+		}
+		if err := verifyEqualMaps(a, decoded); err != nil {
+			t.Fatalf("TestNewWithCompareSetOps failed: %v", err)
+		}
+	}
+}
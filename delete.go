@@ -5,41 +5,93 @@ import (
 	"sort"
 )
 
-func (sm *SortedMap) delete(key interface{}) bool {
-	if val, ok := sm.idx[key]; ok {
+// keyIdx returns the index position of key, which is mapped to val, within a slice of keys sorted by lessFn.
+func (sm *SortedMap) keyIdx(sorted []interface{}, lessFn ComparisonFunc, key, val interface{}) int {
+	smLen := len(sorted)
+	i := sort.Search(smLen, func(i int) bool {
+		return lessFn(val, sm.idx[sorted[i]])
+	})
+
+	if i == smLen {
+		i--
+	} else if i < smLen-1 {
+		i++
+	}
+	for i >= 0 && sorted[i] != key {
+		i--
+	}
+	if i < 0 {
+		return sm.linearKeyIdx(sorted, key)
+	}
+	return i
+}
+
+// sortedKeyIdx returns the index position of key, which is mapped to val, within the collection's sorted keys.
+// The search starts at the first value equal to val, so only keys with equal values are compared.
+func (sm *SortedMap) sortedKeyIdx(key, val interface{}) int {
+	i := sm.lowerBoundIdx(val)
+	for i < len(sm.sorted) && sm.sorted[i] != key {
+		i++
+	}
+	if i == len(sm.sorted) {
+		return sm.linearKeyIdx(sm.sorted, key)
+	}
+	return i
+}
 
-		smLen := len(sm.sorted)
-		i := sort.Search(smLen, func(i int) bool {
-			return sm.lessFn(val, sm.idx[sm.sorted[i]])
-		})
+// linearKeyIdx is used when the comparison function is inconsistent, so the key is not where the binary search expected it to be.
+func (sm *SortedMap) linearKeyIdx(sorted []interface{}, key interface{}) int {
+	sm.reportKeyNotFound(key)
 
-		if i == smLen {
-			i--
-		} else if i < smLen-1 {
-			i++
-		}
-		for sm.sorted[i] != key {
-			i--
-		}
+	i := 0
+	for sorted[i] != key {
+		i++
+	}
+	return i
+}
+
+func (sm *SortedMap) deleteRecord(key interface{}) bool {
+	if val, ok := sm.idx[key]; ok {
+		i := sm.sortedKeyIdx(key, val)
+		sm.deleteFromIndexes(key, val)
+		sm.deleteFromKeyOrder(key)
 
 		delete(sm.idx, key)
 		sm.sorted = deleteInterface(sm.sorted, i)
+		sm.deleteAggregate(i)
 
 		return true
 	}
 	return false
 }
 
+func (sm *SortedMap) delete(key interface{}) bool {
+	if sm.deleteRecord(key) {
+		sm.updateAggregate()
+		sm.wal.logDelete(key)
+		sm.checkOrder("Delete")
+		return true
+	}
+	return false
+}
+
 func (sm *SortedMap) boundedDelete(lowerBound, upperBound interface{}) error {
 	iterBounds := sm.boundsIdxSearch(lowerBound, upperBound)
 	if iterBounds == nil {
 		return errors.New(noValuesErr)
 	}
 	for i, deleted := iterBounds[0], 0; i <= iterBounds[1]-deleted; i++ {
+		sm.deleteFromIndexes(sm.sorted[i], sm.idx[sm.sorted[i]])
+		sm.deleteFromKeyOrder(sm.sorted[i])
 		delete(sm.idx, sm.sorted[i])
 		sm.sorted = deleteInterface(sm.sorted, i)
+		sm.deleteAggregate(i)
 		deleted++
 	}
+	sm.updateAggregate()
+	sm.wal.logBoundedDelete(lowerBound, upperBound)
+	sm.checkOrder("BoundedDelete")
+
 	return nil
 }
 
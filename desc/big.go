@@ -0,0 +1,18 @@
+package desc
+
+import "github.com/umpc/go-sortedmap/asc"
+
+// BigInt is a greater than comparison function for the *big.Int type, where nil is less than all other values.
+func BigInt(i, j interface{}) bool {
+	return asc.BigInt(j, i)
+}
+
+// BigFloat is a greater than comparison function for the *big.Float type, where nil is less than all other values.
+func BigFloat(i, j interface{}) bool {
+	return asc.BigFloat(j, i)
+}
+
+// BigRat is a greater than comparison function for the *big.Rat type, where nil is less than all other values.
+func BigRat(i, j interface{}) bool {
+	return asc.BigRat(j, i)
+}
@@ -0,0 +1,33 @@
+package desc
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	if BigInt(big.NewInt(0), big.NewInt(1)) {
+		t.Fatalf("desc.TestBigInt failed: %v\n", greaterThanErr)
+	}
+	if BigInt((*big.Int)(nil), big.NewInt(0)) {
+		t.Fatalf("desc.TestBigInt failed: %v\n", nilErr)
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	if BigFloat(big.NewFloat(0.5), big.NewFloat(1)) {
+		t.Fatalf("desc.TestBigFloat failed: %v\n", greaterThanErr)
+	}
+	if BigFloat((*big.Float)(nil), big.NewFloat(0)) {
+		t.Fatalf("desc.TestBigFloat failed: %v\n", nilErr)
+	}
+}
+
+func TestBigRat(t *testing.T) {
+	if BigRat(big.NewRat(1, 3), big.NewRat(1, 2)) {
+		t.Fatalf("desc.TestBigRat failed: %v\n", greaterThanErr)
+	}
+	if BigRat((*big.Rat)(nil), big.NewRat(0, 1)) {
+		t.Fatalf("desc.TestBigRat failed: %v\n", nilErr)
+	}
+}
@@ -0,0 +1,6 @@
+package desc
+
+// Bool is a greater than comparison function for the bool type, where true is greater than false.
+func Bool(i, j interface{}) bool {
+	return i.(bool) && !j.(bool)
+}
@@ -0,0 +1,9 @@
+package desc
+
+import "testing"
+
+func TestBool(t *testing.T) {
+	if Bool(false, true) {
+		t.Fatalf("desc.TestBool failed: %v\n", greaterThanErr)
+	}
+}
@@ -1,3 +1,6 @@
 package desc
 
-const greaterThanErr = "the lesser value was greater than the higher value!"
+const (
+	greaterThanErr = "the lesser value was greater than the higher value!"
+	nilErr         = "a nil value was greater than a non-nil value!"
+)
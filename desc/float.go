@@ -0,0 +1,17 @@
+package desc
+
+import "github.com/umpc/go-sortedmap/asc"
+
+// Float64Total is a greater than comparison function for the Float64 numeric type that is safe to use with NaN values.
+// +0 is greater than -0, and NaN values are greater than all other values and are equal to each other,
+// so NaN values are sorted first.
+func Float64Total(i, j interface{}) bool {
+	return asc.Float64Total(j, i)
+}
+
+// Float32Total is a greater than comparison function for the Float32 numeric type that is safe to use with NaN values.
+// +0 is greater than -0, and NaN values are greater than all other values and are equal to each other,
+// so NaN values are sorted first.
+func Float32Total(i, j interface{}) bool {
+	return asc.Float32Total(j, i)
+}
@@ -0,0 +1,18 @@
+package desc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64Total(t *testing.T) {
+	if Float64Total(float64(0), math.NaN()) || Float64Total(math.Copysign(0, -1), float64(0)) {
+		t.Fatalf("desc.TestFloat64Total failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestFloat32Total(t *testing.T) {
+	if Float32Total(float32(0), float32(math.NaN())) || Float32Total(float32(math.Copysign(0, -1)), float32(0)) {
+		t.Fatalf("desc.TestFloat32Total failed: %v\n", greaterThanErr)
+	}
+}
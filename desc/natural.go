@@ -0,0 +1,11 @@
+package desc
+
+import "github.com/umpc/go-sortedmap/asc"
+
+// Natural is a greater than comparison function for the string type that orders strings the way people read them.
+// Runs of digits, including other Unicode decimal digits, are compared by their numeric value, so "item10" is greater than "item2".
+// Other characters are compared case-insensitively.
+// Strings that are otherwise equal, such as "item01" and "item1" or "A" and "a", are ordered by their bytes.
+func Natural(i, j interface{}) bool {
+	return asc.Natural(j, i)
+}
@@ -0,0 +1,9 @@
+package desc
+
+import "testing"
+
+func TestNatural(t *testing.T) {
+	if Natural("item2", "item10") {
+		t.Fatalf("desc.TestNatural failed: %v\n", greaterThanErr)
+	}
+}
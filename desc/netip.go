@@ -0,0 +1,16 @@
+package desc
+
+import "github.com/umpc/go-sortedmap/asc"
+
+// Addr is a greater than comparison function for the netip.Addr type.
+// The zero Addr is less than all other values and IPv4 addresses are less than IPv6 addresses.
+func Addr(i, j interface{}) bool {
+	return asc.Addr(j, i)
+}
+
+// Prefix is a greater than comparison function for the netip.Prefix type.
+// Prefixes are ordered by their address, as with Addr, and then by their length, so that shorter prefixes are less.
+// The zero Prefix is less than all other values.
+func Prefix(i, j interface{}) bool {
+	return asc.Prefix(j, i)
+}
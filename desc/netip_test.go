@@ -0,0 +1,18 @@
+package desc
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestAddr(t *testing.T) {
+	if Addr(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")) {
+		t.Fatalf("desc.TestAddr failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	if Prefix(netip.MustParsePrefix("10.0.0.0/16"), netip.MustParsePrefix("10.0.0.0/24")) {
+		t.Fatalf("desc.TestPrefix failed: %v\n", greaterThanErr)
+	}
+}
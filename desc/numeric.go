@@ -1,5 +1,7 @@
 package desc
 
+import "math/cmplx"
+
 // Uint8 is a greater than comparison function for the Uint8 numeric type.
 func Uint8(i, j interface{}) bool {
 	return i.(uint8) > j.(uint8)
@@ -59,3 +61,13 @@ func Uint(i, j interface{}) bool {
 func Int(i, j interface{}) bool {
 	return i.(int) > j.(int)
 }
+
+// Complex64 is a greater than comparison function for the magnitude of the Complex64 numeric type.
+func Complex64(i, j interface{}) bool {
+	return cmplx.Abs(complex128(i.(complex64))) > cmplx.Abs(complex128(j.(complex64)))
+}
+
+// Complex128 is a greater than comparison function for the magnitude of the Complex128 numeric type.
+func Complex128(i, j interface{}) bool {
+	return cmplx.Abs(i.(complex128)) > cmplx.Abs(j.(complex128))
+}
@@ -73,3 +73,15 @@ func TestInt(t *testing.T) {
 		t.Fatalf("desc.TestInt failed: %v\n", greaterThanErr)
 	}
 }
+
+func TestComplex64(t *testing.T) {
+	if Complex64(complex64(1), complex64(-2i)) {
+		t.Fatalf("desc.TestComplex64 failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestComplex128(t *testing.T) {
+	if Complex128(complex128(1), complex128(-2i)) {
+		t.Fatalf("desc.TestComplex128 failed: %v\n", greaterThanErr)
+	}
+}
@@ -0,0 +1,12 @@
+package desc
+
+import "github.com/umpc/go-sortedmap/asc"
+
+// SemVer is a greater than comparison function for semantic version strings, such as "v1.2.3-rc.1+build.5".
+// Versions are ordered using the precedence rules of Semantic Versioning 2.0.0, where pre-release versions
+// are less than their release and build metadata is ignored.
+// Strings that are not valid versions, including versions with leading zeros such as "01.2.3",
+// are less than all valid versions and are ordered by their bytes.
+func SemVer(i, j interface{}) bool {
+	return asc.SemVer(j, i)
+}
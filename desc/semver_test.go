@@ -0,0 +1,9 @@
+package desc
+
+import "testing"
+
+func TestSemVer(t *testing.T) {
+	if SemVer("1.0.0-rc.1", "1.0.0") {
+		t.Fatalf("desc.TestSemVer failed: %v\n", greaterThanErr)
+	}
+}
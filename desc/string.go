@@ -0,0 +1,28 @@
+package desc
+
+import (
+	"bytes"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+// String is a greater than comparison function for the string type.
+func String(i, j interface{}) bool {
+	return i.(string) > j.(string)
+}
+
+// StringFold is a case-insensitive greater than comparison function for the string type.
+// Strings that are equal under simple Unicode case folding are treated as equal.
+func StringFold(i, j interface{}) bool {
+	return asc.StringFold(j, i)
+}
+
+// Bytes is a greater than comparison function for the []byte type.
+func Bytes(i, j interface{}) bool {
+	return bytes.Compare(i.([]byte), j.([]byte)) > 0
+}
+
+// Rune is a greater than comparison function for the rune type.
+func Rune(i, j interface{}) bool {
+	return i.(rune) > j.(rune)
+}
@@ -0,0 +1,33 @@
+package desc
+
+import "testing"
+
+func TestString(t *testing.T) {
+	if String("a", "b") {
+		t.Fatalf("desc.TestString failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestStringFold(t *testing.T) {
+	if StringFold("a", "B") {
+		t.Fatalf("desc.TestStringFold failed: %v\n", greaterThanErr)
+	}
+	if StringFold("ABC", "abc") || StringFold("abc", "ABC") {
+		t.Fatal("desc.TestStringFold failed: strings that differ only in case were not equal")
+	}
+	if StringFold("A", "ab") {
+		t.Fatalf("desc.TestStringFold failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	if Bytes([]byte("a"), []byte("b")) {
+		t.Fatalf("desc.TestBytes failed: %v\n", greaterThanErr)
+	}
+}
+
+func TestRune(t *testing.T) {
+	if Rune('a', 'b') {
+		t.Fatalf("desc.TestRune failed: %v\n", greaterThanErr)
+	}
+}
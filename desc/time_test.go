@@ -13,3 +13,9 @@ func TestTime(t *testing.T) {
 		t.Fatal("desc.TestTime failed: earlierDate was after laterDate")
 	}
 }
+
+func TestDuration(t *testing.T) {
+	if Duration(time.Minute, time.Hour) {
+		t.Fatalf("desc.TestDuration failed: %v\n", greaterThanErr)
+	}
+}
@@ -0,0 +1,217 @@
+package sortedmap
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ChangeType defines the kind of change that a Change value describes.
+type ChangeType int
+
+// Change types returned by Diff.
+const (
+	Added ChangeType = iota + 1
+	Removed
+	Changed
+)
+
+func (ct ChangeType) String() string {
+	switch ct {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Changed:
+		return "Changed"
+	default:
+		return fmt.Sprintf("ChangeType(%d)", int(ct))
+	}
+}
+
+// Change describes a single record that differs between two collections.
+// OldVal is nil for added records and NewVal is nil for removed records.
+type Change struct {
+	Type ChangeType
+	Key,
+	OldVal,
+	NewVal interface{}
+}
+
+// EqualFunc defines the type of function used to check if two values are equal.
+type EqualFunc func(i, j interface{}) bool
+
+func setEqualFunc(eqFn EqualFunc) EqualFunc {
+	if eqFn == nil {
+		return reflect.DeepEqual
+	}
+	return eqFn
+}
+
+func (c Change) sortVal() interface{} {
+	if c.Type == Removed {
+		return c.OldVal
+	}
+	return c.NewVal
+}
+
+// Diff returns the changes needed to turn oldSm into newSm, using reflect.DeepEqual to compare values.
+// Use DiffFunc for the alternative functionality.
+func Diff(oldSm, newSm *SortedMap) []Change {
+	return DiffFunc(oldSm, newSm, nil)
+}
+
+// DiffFunc returns the changes needed to turn oldSm into newSm, using eqFn to compare values.
+// Changes are sorted by the comparison function of newSm, using the old value of removed records and the new value otherwise.
+// Both collections should be sorted by the same comparison function, so they can be compared in linear time.
+func DiffFunc(oldSm, newSm *SortedMap, eqFn EqualFunc) []Change {
+	eqFn = setEqualFunc(eqFn)
+
+	removed := make([]Change, 0)
+	for _, key := range oldSm.sorted {
+		if _, ok := newSm.idx[key]; !ok {
+			removed = append(removed, Change{Type: Removed, Key: key, OldVal: oldSm.idx[key]})
+		}
+	}
+
+	updated := make([]Change, 0)
+	for _, key := range newSm.sorted {
+		newVal := newSm.idx[key]
+		if oldVal, ok := oldSm.idx[key]; !ok {
+			updated = append(updated, Change{Type: Added, Key: key, NewVal: newVal})
+		} else if !eqFn(oldVal, newVal) {
+			updated = append(updated, Change{Type: Changed, Key: key, OldVal: oldVal, NewVal: newVal})
+		}
+	}
+
+	changes := make([]Change, 0, len(removed)+len(updated))
+
+	i, j := 0, 0
+	for i < len(removed) && j < len(updated) {
+		if newSm.lessFn(updated[j].sortVal(), removed[i].sortVal()) {
+			changes = append(changes, updated[j])
+			j++
+		} else {
+			changes = append(changes, removed[i])
+			i++
+		}
+	}
+	changes = append(changes, removed[i:]...)
+
+	return append(changes, updated[j:]...)
+}
+
+// checkChanges simulates applying changes to the collection, returning the keys of the existing records that are removed or changed,
+// and the final records that are added or changed, in the order that they first appear in changes.
+// An error is returned if any change does not match the collection, or if the final state would be rejected by an Option.
+func (sm *SortedMap) checkChanges(changes []Change) (map[interface{}]bool, []Record, error) {
+	removed := make(map[interface{}]bool)
+	present := make(map[interface{}]bool)
+	final := make(map[interface{}]interface{})
+	var order []interface{}
+
+	for _, c := range changes {
+		// Keys are checked against the collection before any changes, and against earlier changes to the same key.
+		exists := sm.Has(c.Key)
+		has, ok := present[c.Key]
+		if !ok {
+			has = exists
+		}
+
+		switch c.Type {
+		case Added:
+			if exists || has {
+				return nil, nil, fmt.Errorf("Key already exists: %+v", c.Key)
+			}
+		case Removed, Changed:
+			if !exists || !has {
+				return nil, nil, fmt.Errorf("Key not found: %+v", c.Key)
+			}
+			removed[c.Key] = true
+		default:
+			return nil, nil, fmt.Errorf("Invalid change type: %v", c.Type)
+		}
+
+		if c.Type == Removed {
+			present[c.Key] = false
+			delete(final, c.Key)
+			continue
+		}
+		if sm.rejectNaN && isNaN(c.NewVal) {
+			return nil, nil, fmt.Errorf(rejectedValErr, c.Key)
+		}
+		if _, ok := final[c.Key]; !ok {
+			order = append(order, c.Key)
+		}
+		present[c.Key] = true
+		final[c.Key] = c.NewVal
+	}
+
+	recs := make([]Record, 0, len(final))
+	for _, key := range order {
+		if val, ok := final[key]; ok {
+			recs = append(recs, Record{Key: key, Val: val})
+			delete(final, key)
+		}
+	}
+
+	if sm.uniqueVals {
+		if err := sm.checkUniqueRecords(recs, removed); err != nil {
+			return nil, nil, err
+		}
+	}
+	return removed, recs, nil
+}
+
+// checkUniqueRecords returns an error if any two of recs have equal values,
+// or if any of recs has a value that is equal to the value of an existing record that is not in removed.
+func (sm *SortedMap) checkUniqueRecords(recs []Record, removed map[interface{}]bool) error {
+	sortedRecs := append([]Record{}, recs...)
+	sortRecords(sortedRecs, sm.lessFn)
+
+	for i, rec := range sortedRecs {
+		if i > 0 && sm.cmpFn(sortedRecs[i-1].Val, rec.Val) == 0 {
+			return fmt.Errorf(rejectedValErr, rec.Key)
+		}
+		for _, key := range sm.equalRange(rec.Val) {
+			if !removed[key] {
+				return fmt.Errorf(rejectedValErr, rec.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// Apply patches the collection using changes returned by Diff.
+// Added keys must not exist in the collection and removed or changed keys must exist.
+// Changes are checked against the state of the collection after all of them are applied,
+// so that values can be swapped between keys when the UniqueValues option is used.
+// If any change does not match the collection, or is rejected by an Option, an error is returned and no changes are applied.
+func (sm *SortedMap) Apply(changes []Change) error {
+	const applyRejectedErr = "Change rejected while applying for key: %+v"
+
+	removed, recs, err := sm.checkChanges(changes)
+	if err != nil {
+		return err
+	}
+
+	// Removed and changed records are deleted before any records are inserted,
+	// and the log records the same steps, so that replaying it does not reject swapped values.
+	for _, c := range changes {
+		if removed[c.Key] {
+			sm.deleteRecord(c.Key)
+			sm.wal.logDelete(c.Key)
+			delete(removed, c.Key)
+		}
+	}
+	for _, rec := range recs {
+		if !sm.insertRecord(rec.Key, rec.Val) {
+			sm.updateAggregate()
+			return fmt.Errorf(applyRejectedErr, rec.Key)
+		}
+		sm.wal.logInsert(rec.Key, rec.Val)
+	}
+	sm.updateAggregate()
+	sm.checkOrder("Apply")
+
+	return nil
+}
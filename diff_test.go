@@ -0,0 +1,121 @@
+package sortedmap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func TestDiff(t *testing.T) {
+	oldSm := New(4, asc.Int)
+	oldSm.Insert("a", 1)
+	oldSm.Insert("b", 3)
+	oldSm.Insert("c", 5)
+	oldSm.Insert("d", 7)
+
+	newSm := New(4, asc.Int)
+	newSm.Insert("a", 1)
+	newSm.Insert("c", 2)
+	newSm.Insert("e", 4)
+	newSm.Insert("d", 7)
+
+	expected := []Change{
+		{Type: Changed, Key: "c", OldVal: 5, NewVal: 2},
+		{Type: Removed, Key: "b", OldVal: 3},
+		{Type: Added, Key: "e", NewVal: 4},
+	}
+
+	changes := Diff(oldSm, newSm)
+	if len(changes) != len(expected) {
+		t.Fatalf("TestDiff failed: Expected: %+v, Had: %+v.", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Fatalf("TestDiff failed: Expected: %+v, Had: %+v.", expected[i], changes[i])
+		}
+	}
+
+	if err := oldSm.Apply(changes); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(newSm, oldSm); err != nil {
+		t.Fatalf("TestDiff failed: %v", err)
+	}
+	if changes := Diff(oldSm, newSm); len(changes) != 0 {
+		t.Fatalf("TestDiff failed: equal collections had changes: %+v", changes)
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	oldSm, records, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSm, _, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range records[:100] {
+		newSm.Insert(rec.Key, rec.Val)
+	}
+	for _, rec := range records[100:150] {
+		newSm.Insert(rec.Key, maxTime)
+	}
+
+	changes := DiffFunc(oldSm, newSm, func(i, j interface{}) bool {
+		return i.(time.Time).Equal(j.(time.Time))
+	})
+	if len(changes) != 500 {
+		t.Fatalf("TestDiffFunc failed: Expected: 500 changes, Had: %v.", len(changes))
+	}
+	for i := 1; i < len(changes); i++ {
+		if asc.Time(changes[i].sortVal(), changes[i-1].sortVal()) {
+			t.Fatalf("TestDiffFunc failed: %v", unsortedErr)
+		}
+	}
+
+	if err := oldSm.Apply(changes); err != nil {
+		t.Fatal(err)
+	}
+	if oldSm.Len() != newSm.Len() {
+		t.Fatalf("TestDiffFunc failed: Expected: %v records, Had: %v.", newSm.Len(), oldSm.Len())
+	}
+	for _, key := range newSm.Keys() {
+		if oldSm.idx[key] != newSm.idx[key] {
+			t.Fatalf("TestDiffFunc failed: value mismatch for key %+v.", key)
+		}
+	}
+}
+
+func TestApplyInvalidChanges(t *testing.T) {
+	sm := New(1, asc.Int)
+	sm.Insert("a", 1)
+
+	for _, c := range []Change{
+		{Type: Added, Key: "a", NewVal: 2},
+		{Type: Removed, Key: "b", OldVal: 2},
+		{Type: Changed, Key: "b", OldVal: 1, NewVal: 2},
+		{Key: "a"},
+	} {
+		if err := sm.Apply([]Change{{Type: Removed, Key: "a"}, c}); err == nil {
+			t.Fatalf("TestApplyInvalidChanges failed: an invalid change was applied: %+v", c)
+		}
+		if !sm.Has("a") {
+			t.Fatal("TestApplyInvalidChanges failed: changes were partially applied.")
+		}
+	}
+}
+
+func TestChangeTypeString(t *testing.T) {
+	for ct, expected := range map[ChangeType]string{
+		Added:         "Added",
+		Removed:       "Removed",
+		Changed:       "Changed",
+		ChangeType(0): "ChangeType(0)",
+	} {
+		if ct.String() != expected {
+			t.Fatalf("TestChangeTypeString failed: Expected: %v, Had: %v.", expected, ct.String())
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package sortedmap
+
+// Equal checks if both collections contain the same keys in the same order, with equal values.
+// Values are compared using valEq, or reflect.DeepEqual if valEq is nil.
+func (sm *SortedMap) Equal(other *SortedMap, valEq EqualFunc) bool {
+	if len(sm.sorted) != len(other.sorted) {
+		return false
+	}
+	valEq = setEqualFunc(valEq)
+
+	for i, key := range sm.sorted {
+		if key != other.sorted[i] {
+			return false
+		}
+		if !valEq(sm.idx[key], other.idx[key]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package sortedmap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func TestEqual(t *testing.T) {
+	sm, records, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := New(0, asc.Time)
+	other.BatchInsert(records)
+
+	if !sm.Equal(other, nil) {
+		t.Fatal("TestEqual failed: equal collections were not equal.")
+	}
+
+	other.Replace(records[0].Key, maxTime)
+	if sm.Equal(other, nil) {
+		t.Fatal("TestEqual failed: collections with different values were equal.")
+	}
+
+	other.Delete(records[0].Key)
+	if sm.Equal(other, nil) {
+		t.Fatal("TestEqual failed: collections with different lengths were equal.")
+	}
+}
+
+func TestEqualOrder(t *testing.T) {
+	a := New(2, asc.Int)
+	a.Insert("a", 1)
+	a.Insert("b", 1)
+
+	b := New(2, asc.Int)
+	b.Insert("b", 1)
+	b.Insert("a", 1)
+
+	if a.Equal(b, nil) {
+		t.Fatal("TestEqualOrder failed: collections with different orders were equal.")
+	}
+}
+
+func TestEqualWithValEq(t *testing.T) {
+	utc := time.Date(2017, 6, 5, 18, 0, 0, 0, time.UTC)
+	local := utc.In(time.FixedZone("UTC+1", 60*60))
+
+	a := New(1, asc.Time)
+	a.Insert("a", utc)
+	b := New(1, asc.Time)
+	b.Insert("a", local)
+
+	if a.Equal(b, nil) {
+		t.Fatal("TestEqualWithValEq failed: values with different locations were deeply equal.")
+	}
+	if !a.Equal(b, func(i, j interface{}) bool {
+		return i.(time.Time).Equal(j.(time.Time))
+	}) {
+		t.Fatal("TestEqualWithValEq failed: values representing the same instant were not equal.")
+	}
+}
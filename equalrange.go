@@ -0,0 +1,41 @@
+package sortedmap
+
+// equalRange returns the sorted keys that are mapped to values equal to val, without copying them.
+func (sm *SortedMap) equalRange(val interface{}) []interface{} {
+	lowerBoundIdx := sm.lowerBoundIdx(val)
+	upperBoundIdx := lowerBoundIdx
+	for upperBoundIdx < len(sm.sorted) && sm.cmpFn(sm.idx[sm.sorted[upperBoundIdx]], val) == 0 {
+		upperBoundIdx++
+	}
+	return sm.sorted[lowerBoundIdx:upperBoundIdx]
+}
+
+// EqualRange returns the keys that are mapped to values equal to val, in sorted order.
+// The first key is found using a binary search, so EqualRange takes O(log n + m) time, where m is the number of keys returned.
+// An empty slice is returned if no values are equal to val.
+func (sm *SortedMap) EqualRange(val interface{}) []interface{} {
+	keys := sm.equalRange(val)
+	return append(make([]interface{}, 0, len(keys)), keys...)
+}
+
+// CountEqual returns the number of values in the collection that are equal to val, in O(log n) time.
+func (sm *SortedMap) CountEqual(val interface{}) int {
+	return sm.upperBoundIdx(val) - sm.lowerBoundIdx(val)
+}
+
+// FirstWithValue returns the first key in sorted order that is mapped to a value equal to val, in O(log n) time.
+// The second result is false if no values are equal to val.
+func (sm *SortedMap) FirstWithValue(val interface{}) (interface{}, bool) {
+	i := sm.lowerBoundIdx(val)
+	if i == len(sm.sorted) || sm.cmpFn(sm.idx[sm.sorted[i]], val) != 0 {
+		return nil, false
+	}
+	return sm.sorted[i], true
+}
+
+// KeyOf returns the key that holds a value equal to val, in O(log n) time.
+// It is intended for collections created with the UniqueValues option,
+// and otherwise returns the same key as FirstWithValue.
+func (sm *SortedMap) KeyOf(val interface{}) (interface{}, bool) {
+	return sm.FirstWithValue(val)
+}
@@ -0,0 +1,244 @@
+package sortedmap
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func newEqualRangeTestMap(opts ...Option) *SortedMap {
+	sm := New(0, asc.Int, opts...)
+	for i, val := range []int{5, 1, 3, 3, 9, 3, 7} {
+		sm.Insert(i, val)
+	}
+	return sm
+}
+
+func TestEqualRange(t *testing.T) {
+	sm := newEqualRangeTestMap()
+
+	keys := sm.EqualRange(3)
+	if len(keys) != 3 || sm.CountEqual(3) != 3 {
+		t.Fatalf("TestEqualRange failed: expected 3 keys, got %v and a count of %v.", keys, sm.CountEqual(3))
+	}
+	for _, key := range keys {
+		if sm.idx[key] != 3 {
+			t.Fatalf("TestEqualRange failed: key %v has the value %v.", key, sm.idx[key])
+		}
+	}
+	if key, ok := sm.FirstWithValue(3); !ok || key != keys[0] {
+		t.Fatalf("TestEqualRange failed: FirstWithValue returned %v, expected %v.", key, keys[0])
+	}
+
+	for _, val := range []int{0, 4, 10} {
+		if len(sm.EqualRange(val)) != 0 || sm.CountEqual(val) != 0 {
+			t.Fatalf("TestEqualRange failed: found values equal to %v.", val)
+		}
+		if _, ok := sm.FirstWithValue(val); ok {
+			t.Fatalf("TestEqualRange failed: FirstWithValue found a value equal to %v.", val)
+		}
+	}
+	if key, ok := sm.FirstWithValue(9); !ok || key != 4 {
+		t.Fatalf("TestEqualRange failed: FirstWithValue returned %v, expected 4.", key)
+	}
+	if len(New(0, asc.Int).EqualRange(1)) != 0 {
+		t.Fatal("TestEqualRange failed: found a value in an empty collection.")
+	}
+}
+
+func TestIndexEqualRange(t *testing.T) {
+	sm, _ := newIndexTestMap(t, 300)
+	ix, _ := sm.Index("priority")
+
+	count := 0
+	for _, rec := range sm.Map() {
+		if rec.(indexTestVal).Priority == 5 {
+			count++
+		}
+	}
+
+	val := indexTestVal{Priority: 5}
+	keys := ix.EqualRange(val)
+	if len(keys) != count || ix.CountEqual(val) != count {
+		t.Fatalf("TestIndexEqualRange failed: expected %v keys, got %v.", count, len(keys))
+	}
+	if key, ok := ix.FirstWithValue(val); count > 0 && (!ok || key != keys[0]) {
+		t.Fatalf("TestIndexEqualRange failed: FirstWithValue returned %v, expected %v.", key, keys[0])
+	}
+}
+
+func TestUniqueValues(t *testing.T) {
+	sm := newEqualRangeTestMap(UniqueValues())
+	if sm.Len() != 5 {
+		t.Fatalf("TestUniqueValues failed: expected 5 records, got %v.", sm.Len())
+	}
+
+	if key, ok := sm.KeyOf(3); !ok || key != 2 {
+		t.Fatalf("TestUniqueValues failed: KeyOf returned %v, expected 2.", key)
+	}
+	if _, ok := sm.KeyOf(4); ok {
+		t.Fatal("TestUniqueValues failed: KeyOf found a missing value.")
+	}
+
+	sm.Replace(0, 9)
+	if sm.idx[0] != 5 {
+		t.Fatal("TestUniqueValues failed: Replace added a value held by another key.")
+	}
+	sm.Replace(0, 5)
+	sm.Replace(0, 6)
+	if key, ok := sm.KeyOf(6); !ok || key != 0 {
+		t.Fatalf("TestUniqueValues failed: KeyOf returned %v, expected 0.", key)
+	}
+
+	if err := sm.BatchInsertMap(map[interface{}]interface{}{"a": 7}); err == nil {
+		t.Fatal("TestUniqueValues failed: BatchInsertMap did not return an error.")
+	}
+	if !sm.Clone().uniqueVals {
+		t.Fatal("TestUniqueValues failed: the clone did not keep the option.")
+	}
+}
+
+func TestUniqueValuesApply(t *testing.T) {
+	sm := New(0, asc.Int, UniqueValues())
+	sm.Insert("a", 1)
+	sm.Insert("x", 5)
+
+	for _, changes := range [][]Change{
+		{{Type: Added, Key: "y", NewVal: 7}, {Type: Added, Key: "z", NewVal: 7}},
+		{{Type: Added, Key: "y", NewVal: 5}},
+		{{Type: Changed, Key: "a", OldVal: 1, NewVal: 5}},
+		{{Type: Added, Key: "y", NewVal: 8}, {Type: Added, Key: "y", NewVal: 9}},
+	} {
+		if err := sm.Apply(changes); err == nil {
+			t.Fatalf("TestUniqueValuesApply failed: changes were applied: %+v", changes)
+		}
+		if sm.Len() != 2 || sm.idx["a"] != 1 || sm.idx["x"] != 5 {
+			t.Fatalf("TestUniqueValuesApply failed: changes were partially applied: %v", sm.Map())
+		}
+	}
+
+	// Swapping values between keys is valid once all changes are applied.
+	swapped := New(0, asc.Int)
+	swapped.Insert("a", 5)
+	swapped.Insert("x", 1)
+	swapped.Insert("y", 3)
+	if err := sm.Apply(Diff(sm, swapped)); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(swapped, sm); err != nil {
+		t.Fatalf("TestUniqueValuesApply failed: %v", err)
+	}
+
+	// Removing a key frees its value for another key.
+	if err := sm.Apply([]Change{{Type: Removed, Key: "y", OldVal: 3}, {Type: Added, Key: "z", NewVal: 3}}); err != nil {
+		t.Fatal(err)
+	}
+	if key, ok := sm.KeyOf(3); !ok || key != "z" {
+		t.Fatalf("TestUniqueValuesApply failed: KeyOf returned %v, expected z.", key)
+	}
+}
+
+func TestUniqueValuesSetOps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+
+	sm := New(0, asc.Int, UniqueValues())
+	sm.SetCodecs(StringCodec, IntCodec)
+	wal, err := sm.OpenWAL(path, WALParams{SyncPolicy: SyncNever})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm.Insert("a", 1)
+	sm.Insert("b", 2)
+
+	other := New(0, asc.Int)
+	other.Insert("a", 4)
+	other.Insert("b", 5)
+	other.Insert("c", 2)
+	other.Insert("d", 3)
+	other.Insert("e", 3)
+
+	for _, result := range []*SortedMap{Union(sm, other, sumVals), Intersect(sm, other, sumVals)} {
+		for _, key := range result.Keys() {
+			if n := result.CountEqual(result.idx[key]); n != 1 {
+				t.Fatalf("TestUniqueValuesSetOps failed: %v keys hold the value %v.", n, result.idx[key])
+			}
+		}
+	}
+
+	// c and e are skipped because their values are already held, and b keeps its value because a is given 7 first.
+	sm.MergeFrom(other, func(_, _, _ interface{}) interface{} {
+		return 7
+	})
+	if err := verifyRecs(sm, []Record{{Key: "b", Val: 2}, {Key: "d", Val: 3}, {Key: "a", Val: 7}}); err != nil {
+		t.Fatalf("TestUniqueValuesSetOps failed: %v", err)
+	}
+	// b keeps its value because 2+5 is held by a.
+	sm.IntersectFrom(other, sumVals)
+	if err := verifyRecs(sm, []Record{{Key: "b", Val: 2}, {Key: "d", Val: 6}, {Key: "a", Val: 11}}); err != nil {
+		t.Fatalf("TestUniqueValuesSetOps failed: %v", err)
+	}
+
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := New(0, asc.Int, UniqueValues())
+	replayed.SetCodecs(StringCodec, IntCodec)
+	wal, err = replayed.OpenWAL(path, WALParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wal.Close()
+
+	if err := verifyEqualMaps(sm, replayed); err != nil {
+		t.Fatalf("TestUniqueValuesSetOps failed: %v", err)
+	}
+}
+
+func TestUniqueValuesDecode(t *testing.T) {
+	src := New(0, asc.Int)
+	src.Insert("a", 1)
+	src.Insert("b", 1)
+	src.Insert("c", 2)
+	src.SetCodecs(StringCodec, IntCodec)
+
+	gobData, err := src.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := src.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := src.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, decode := range map[string]func(sm *SortedMap) error{
+		"GobDecode": func(sm *SortedMap) error {
+			return sm.GobDecode(gobData)
+		},
+		"UnmarshalJSON": func(sm *SortedMap) error {
+			sm.SetJSONDecoders(nil, decodeJSONInt)
+			return sm.UnmarshalJSON(jsonData)
+		},
+		"ReadFrom": func(sm *SortedMap) error {
+			_, err := sm.ReadFrom(bytes.NewReader(buf.Bytes()))
+			return err
+		},
+	} {
+		sm := New(0, asc.Int, UniqueValues())
+		sm.SetCodecs(StringCodec, IntCodec)
+		sm.Insert("z", 9)
+
+		if err := decode(sm); err == nil {
+			t.Fatalf("TestUniqueValuesDecode failed: %v accepted a value held by two keys.", name)
+		}
+		if sm.Len() != 1 || sm.idx["z"] != 9 {
+			t.Fatalf("TestUniqueValuesDecode failed: %v changed the collection: %v", name, sm.Map())
+		}
+	}
+}
@@ -1,3 +1,9 @@
 package sortedmap
 
-const noValuesErr = "No values found that were equal to or within the given bounds."
+const (
+	noValuesErr        = "No values found that were equal to or within the given bounds."
+	duplicateKeyErr    = "Duplicate key found while decoding: %+v"
+	unsortedDataErr    = "Decoded records are not sorted by the comparison function."
+	rejectedValErr     = "Value rejected for key: %+v"
+	uncomparableKeyErr = "Decoded key is not comparable, so it cannot be used as a map key: %+v"
+)
@@ -0,0 +1,111 @@
+package sortedmap
+
+// RangeParams contains the range and direction used by the functional helpers, such as Filter and Reduce.
+// LowerBound and UpperBound default to the whole collection when left unset, and records are visited in sorted order
+// unless Reversed is set to true. Records are selected in the same way as BoundedKeys, so a value equal to LowerBound
+// is only within the range when it is the greatest value in the collection.
+type RangeParams struct {
+	Reversed bool
+	LowerBound,
+	UpperBound interface{}
+}
+
+// PredicateFunc defines the type of function used to test records.
+type PredicateFunc func(rec Record) bool
+
+// MapFunc defines the type of function used to compute a new value from a record.
+type MapFunc func(rec Record) interface{}
+
+// rangeFunc passes each record within the range to f, stopping when f returns false.
+// A range without any values is not an error, and f is not called.
+func (sm *SortedMap) rangeFunc(params RangeParams, f IterCallbackFunc) {
+	sm.iterFunc(params.Reversed, params.LowerBound, params.UpperBound, f)
+}
+
+// Filter returns a new collection containing the records within the range that match pred, in O(n) time, without sorting.
+// The new collection has the same comparison function, options, codecs and JSON decoders, while indexes, key order and an attached WAL are not copied.
+func (sm *SortedMap) Filter(params RangeParams, pred PredicateFunc) *SortedMap {
+	idx := make(map[interface{}]interface{})
+	sorted := make([]interface{}, 0)
+
+	sm.rangeFunc(params, func(rec Record) bool {
+		if pred(rec) {
+			idx[rec.Key] = rec.Val
+			sorted = append(sorted, rec.Key)
+		}
+		return true
+	})
+	if params.Reversed {
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+
+	filtered := sm.emptyCopy()
+	filtered.setRecords(idx, sorted)
+
+	return filtered
+}
+
+// MapValues returns a new collection created by New with cmpFn, where each record within the range is mapped to the value returned by fn.
+// The new values are sorted in O(n log n) time.
+func (sm *SortedMap) MapValues(params RangeParams, fn MapFunc, cmpFn ComparisonFunc) *SortedMap {
+	recs := make([]Record, 0)
+	sm.rangeFunc(params, func(rec Record) bool {
+		recs = append(recs, Record{Key: rec.Key, Val: fn(rec)})
+		return true
+	})
+
+	mapped := New(len(recs), cmpFn)
+	sortRecords(recs, mapped.lessFn)
+	mapped.setSortedRecords(recs)
+
+	return mapped
+}
+
+// Reduce folds the records within the range into a single result, starting with init.
+func (sm *SortedMap) Reduce(params RangeParams, init interface{}, fn ReduceFunc) interface{} {
+	acc := init
+	sm.rangeFunc(params, func(rec Record) bool {
+		acc = fn(acc, rec)
+		return true
+	})
+	return acc
+}
+
+// FindFirst returns the first record within the range that matches pred, stopping at the first match.
+// The second result is false if no records match.
+func (sm *SortedMap) FindFirst(params RangeParams, pred PredicateFunc) (Record, bool) {
+	var (
+		found Record
+		ok    bool
+	)
+	sm.rangeFunc(params, func(rec Record) bool {
+		if pred(rec) {
+			found, ok = rec, true
+		}
+		return !ok
+	})
+	return found, ok
+}
+
+// FindLast returns the last record within the range that matches pred, by searching the range in the opposite direction.
+// The second result is false if no records match.
+func (sm *SortedMap) FindLast(params RangeParams, pred PredicateFunc) (Record, bool) {
+	params.Reversed = !params.Reversed
+	return sm.FindFirst(params, pred)
+}
+
+// AnyMatch returns true if any record within the range matches pred, stopping at the first match.
+func (sm *SortedMap) AnyMatch(params RangeParams, pred PredicateFunc) bool {
+	_, ok := sm.FindFirst(params, pred)
+	return ok
+}
+
+// AllMatch returns true if every record within the range matches pred, stopping at the first record that does not.
+// AllMatch returns true for a range without any values.
+func (sm *SortedMap) AllMatch(params RangeParams, pred PredicateFunc) bool {
+	return !sm.AnyMatch(params, func(rec Record) bool {
+		return !pred(rec)
+	})
+}
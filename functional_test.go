@@ -0,0 +1,114 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func newFunctionalTestMap() *SortedMap {
+	sm := New(0, asc.Int, RejectNaN())
+	for i := 0; i < 20; i++ {
+		sm.Insert(i, i*10)
+	}
+	return sm
+}
+
+func isEvenVal(rec Record) bool {
+	return rec.Val.(int)%20 == 0
+}
+
+func TestFilter(t *testing.T) {
+	sm := newFunctionalTestMap()
+
+	for _, reversed := range []bool{false, true} {
+		filtered := sm.Filter(RangeParams{Reversed: reversed, LowerBound: 35, UpperBound: 150}, isEvenVal)
+		keys := filtered.Keys()
+		if len(keys) != 6 || keys[0] != 4 || keys[5] != 14 {
+			t.Fatalf("TestFilter failed: unexpected keys: %v", keys)
+		}
+		if !filtered.rejectNaN {
+			t.Fatal("TestFilter failed: options were not copied.")
+		}
+		filtered.Insert(100, 5)
+		if sm.Has(100) || filtered.Keys()[0] != 100 {
+			t.Fatal("TestFilter failed: the filtered collection was not independent and sorted.")
+		}
+	}
+
+	if sm.Filter(RangeParams{LowerBound: 1000}, isEvenVal).Len() != 0 {
+		t.Fatal("TestFilter failed: records were found in an empty range.")
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	sm := newFunctionalTestMap()
+
+	mapped := sm.MapValues(RangeParams{UpperBound: 40}, func(rec Record) interface{} {
+		return -rec.Val.(int)
+	}, desc.Int)
+
+	keys := mapped.Keys()
+	if len(keys) != 5 || keys[0] != 0 || keys[4] != 4 || mapped.idx[4] != -40 {
+		t.Fatalf("TestMapValues failed: unexpected keys: %v", keys)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sm := newFunctionalTestMap()
+
+	sum := sm.Reduce(RangeParams{}, 0, func(acc interface{}, rec Record) interface{} {
+		return acc.(int) + rec.Val.(int)
+	})
+	if sum != 1900 {
+		t.Fatalf("TestReduce failed: expected 1900, got %v.", sum)
+	}
+
+	// The value 150 equals the lower bound, so it is excluded, as by BoundedKeys.
+	order := sm.Reduce(RangeParams{Reversed: true, LowerBound: 150}, "", func(acc interface{}, rec Record) interface{} {
+		return acc.(string) + string(rune('a'+rec.Key.(int)))
+	})
+	if order != "tsrq" {
+		t.Fatalf("TestReduce failed: unexpected order: %v", order)
+	}
+}
+
+func TestFind(t *testing.T) {
+	sm := newFunctionalTestMap()
+	calls := 0
+	over55 := func(rec Record) bool {
+		calls++
+		return rec.Val.(int) > 55
+	}
+
+	if rec, ok := sm.FindFirst(RangeParams{}, over55); !ok || rec.Key != 6 || calls != 7 {
+		t.Fatalf("TestFind failed: FindFirst returned %+v after %v calls.", rec, calls)
+	}
+	if rec, ok := sm.FindLast(RangeParams{UpperBound: 100}, over55); !ok || rec.Key != 10 {
+		t.Fatalf("TestFind failed: FindLast returned %+v.", rec)
+	}
+	if rec, ok := sm.FindLast(RangeParams{Reversed: true}, over55); !ok || rec.Key != 6 {
+		t.Fatalf("TestFind failed: reversed FindLast returned %+v.", rec)
+	}
+	if _, ok := sm.FindFirst(RangeParams{UpperBound: 50}, over55); ok {
+		t.Fatal("TestFind failed: FindFirst found a record that did not match.")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	sm := newFunctionalTestMap()
+	isTens := func(rec Record) bool {
+		return rec.Val.(int)%10 == 0
+	}
+
+	if !sm.AllMatch(RangeParams{}, isTens) || sm.AllMatch(RangeParams{}, isEvenVal) {
+		t.Fatal("TestMatch failed: unexpected AllMatch result.")
+	}
+	if !sm.AnyMatch(RangeParams{}, isEvenVal) || sm.AnyMatch(RangeParams{LowerBound: 1000}, isTens) {
+		t.Fatal("TestMatch failed: unexpected AnyMatch result.")
+	}
+	if !sm.AllMatch(RangeParams{LowerBound: 1000}, isEvenVal) {
+		t.Fatal("TestMatch failed: AllMatch was false for an empty range.")
+	}
+}
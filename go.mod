@@ -0,0 +1,3 @@
+module github.com/umpc/go-sortedmap
+
+go 1.20
@@ -0,0 +1,74 @@
+package sortedmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+func (sm *SortedMap) records() []Record {
+	recs := make([]Record, len(sm.sorted))
+	for i := range sm.sorted {
+		recs[i] = sm.recordFromIdx(i)
+	}
+	return recs
+}
+
+// loadSorted replaces the contents of the collection with records that are already sorted.
+// The order, and the values rejected by the options given to New, are verified in a single pass,
+// so loading takes O(n) time instead of insert sorting each record.
+func (sm *SortedMap) loadSorted(recs []Record) error {
+	lessFn := setComparisonFunc(sm.lessFn)
+	cmpFn := sm.cmpFn
+	if cmpFn == nil {
+		cmpFn = compareFromLess(lessFn)
+	}
+	idx := make(map[interface{}]interface{}, len(recs))
+	sorted := make([]interface{}, len(recs))
+
+	for i, rec := range recs {
+		if _, ok := idx[rec.Key]; ok {
+			return fmt.Errorf(duplicateKeyErr, rec.Key)
+		}
+		if sm.rejectNaN && isNaN(rec.Val) {
+			return fmt.Errorf(rejectedValErr, rec.Key)
+		}
+		if sm.uniqueVals && i > 0 && cmpFn(recs[i-1].Val, rec.Val) == 0 {
+			return fmt.Errorf(rejectedValErr, rec.Key)
+		}
+		if i > 0 && lessFn(rec.Val, recs[i-1].Val) {
+			return errors.New(unsortedDataErr)
+		}
+		idx[rec.Key] = rec.Val
+		sorted[i] = rec.Key
+	}
+
+	sm.lessFn, sm.cmpFn = lessFn, cmpFn
+	sm.setRecords(idx, sorted)
+
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// Records are written in sorted order, so decoding them does not require sorting.
+// Concrete key and value types must be registered using gob.Register.
+func (sm *SortedMap) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(sm.records()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// The comparison function of the receiver is kept, so the destination should be created using New
+// with the same comparison function that the encoded SortedMap was sorted with.
+// An error is returned if the decoded records are not in order under that comparison function.
+func (sm *SortedMap) GobDecode(data []byte) error {
+	var recs []Record
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&recs); err != nil {
+		return err
+	}
+	return sm.loadSorted(recs)
+}
@@ -0,0 +1,120 @@
+package sortedmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+func verifyEqualMaps(a, b *SortedMap) error {
+	if a.Len() != b.Len() {
+		return fmt.Errorf("length mismatch. Expected: %v, Had: %v.", a.Len(), b.Len())
+	}
+	for i := range a.sorted {
+		if a.sorted[i] != b.sorted[i] {
+			return fmt.Errorf("key mismatch at index %v. Expected: %+v, Had: %+v.", i, a.sorted[i], b.sorted[i])
+		}
+		if a.idx[a.sorted[i]] != b.idx[b.sorted[i]] {
+			return fmt.Errorf("value mismatch for key %+v.", a.sorted[i])
+		}
+	}
+	return nil
+}
+
+func gobRoundTrip(src, dst *SortedMap) error {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(src); err != nil {
+		return err
+	}
+	return gob.NewDecoder(buf).Decode(dst)
+}
+
+func TestGobRoundTripAsc(t *testing.T) {
+	sm, _, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := New(0, asc.Time)
+	if err := gobRoundTrip(sm, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(sm, decoded); err != nil {
+		t.Fatalf("TestGobRoundTripAsc failed: %v", err)
+	}
+
+	iterCh, err := decoded.IterCh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iterCh.Close()
+
+	if err := verifyRecords(iterCh.Records(), false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGobRoundTripDesc(t *testing.T) {
+	sm := New(100, desc.Int)
+	for i := 0; i < 100; i++ {
+		sm.Insert(randStr(16), mrand.Intn(1000))
+	}
+	decoded := New(0, desc.Int)
+	if err := gobRoundTrip(sm, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(sm, decoded); err != nil {
+		t.Fatalf("TestGobRoundTripDesc failed: %v", err)
+	}
+	if !decoded.Insert("new", 500) {
+		t.Fatalf("TestGobRoundTripDesc failed: %v", keyExistsErr)
+	}
+}
+
+func TestGobRoundTripEmpty(t *testing.T) {
+	decoded := New(0, asc.Int)
+	decoded.Insert("stale", 1)
+	if err := gobRoundTrip(New(0, asc.Int), decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Len() != 0 {
+		t.Fatalf("TestGobRoundTripEmpty failed: expected an empty SortedMap, Had: %v records.", decoded.Len())
+	}
+}
+
+func TestGobDecodeWithMismatchedComparisonFunc(t *testing.T) {
+	sm := New(3, asc.Int)
+	sm.Insert("a", 1)
+	sm.Insert("b", 2)
+	sm.Insert("c", 3)
+
+	if err := gobRoundTrip(sm, New(0, desc.Int)); err == nil {
+		t.Fatal("TestGobDecodeWithMismatchedComparisonFunc failed: unsorted records were accepted.")
+	}
+}
+
+func TestGobDecodeWithDuplicateKeys(t *testing.T) {
+	buf := new(bytes.Buffer)
+	recs := []Record{{Key: "a", Val: 1}, {Key: "a", Val: 2}}
+	if err := gob.NewEncoder(buf).Encode(recs); err != nil {
+		t.Fatal(err)
+	}
+	if err := New(0, asc.Int).GobDecode(buf.Bytes()); err == nil {
+		t.Fatal("TestGobDecodeWithDuplicateKeys failed: duplicate keys were accepted.")
+	}
+}
+
+func TestGobDecodeInvalidData(t *testing.T) {
+	if err := New(0, asc.Int).GobDecode([]byte("invalid")); err == nil {
+		t.Fatal("TestGobDecodeInvalidData failed: invalid data was accepted.")
+	}
+}
@@ -0,0 +1,99 @@
+package sortedmap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// BucketFunc defines the type of function used to map a value to the boundary of the bucket that it belongs to.
+// Bucket boundaries are compared using the collection's comparison function, so they must have the same type as the values,
+// and the function must preserve the collection's order.
+type BucketFunc func(val interface{}) interface{}
+
+// ReduceFunc defines the type of function used to fold records into an accumulated result.
+type ReduceFunc func(acc interface{}, rec Record) interface{}
+
+// Bucket contains the boundary of a bucket returned by GroupBy and the result of reducing its records.
+type Bucket struct {
+	Bound,
+	Result interface{}
+}
+
+const invalidBucketWidthErr = "Bucket width must be a finite number greater than zero: %v"
+
+// TimeBucket returns a BucketFunc that truncates time.Time values to a multiple of d since the zero time.
+func TimeBucket(d time.Duration) BucketFunc {
+	return func(val interface{}) interface{} {
+		return val.(time.Time).Truncate(d)
+	}
+}
+
+// IntBucket returns a BucketFunc that rounds int values down to a multiple of width.
+// IntBucket panics if width is not greater than zero.
+func IntBucket(width int) BucketFunc {
+	if width <= 0 {
+		panic(fmt.Sprintf(invalidBucketWidthErr, width))
+	}
+	return func(val interface{}) interface{} {
+		v := val.(int)
+		r := v % width
+		if r < 0 {
+			r += width
+		}
+		return v - r
+	}
+}
+
+// Int64Bucket returns a BucketFunc that rounds int64 values down to a multiple of width.
+// Int64Bucket panics if width is not greater than zero.
+func Int64Bucket(width int64) BucketFunc {
+	if width <= 0 {
+		panic(fmt.Sprintf(invalidBucketWidthErr, width))
+	}
+	return func(val interface{}) interface{} {
+		v := val.(int64)
+		r := v % width
+		if r < 0 {
+			r += width
+		}
+		return v - r
+	}
+}
+
+// Float64Bucket returns a BucketFunc that rounds float64 values down to a multiple of width.
+// Float64Bucket panics if width is not a finite number greater than zero.
+func Float64Bucket(width float64) BucketFunc {
+	if !(width > 0) || math.IsInf(width, 1) {
+		panic(fmt.Sprintf(invalidBucketWidthErr, width))
+	}
+	return func(val interface{}) interface{} {
+		return math.Floor(val.(float64)/width) * width
+	}
+}
+
+// GroupBy splits the records with values within the given bounds, as selected by BoundedKeys, into buckets using bucketFn,
+// and folds the records of each bucket using reduceFn, which is passed a nil accumulator for the first record of each bucket.
+// Buckets are returned in sorted order, and only one accumulator is held at a time, so records are not copied.
+// Either bound can be nil to leave the range unbounded.
+func (sm *SortedMap) GroupBy(lowerBound, upperBound interface{}, bucketFn BucketFunc, reduceFn ReduceFunc) ([]Bucket, error) {
+	iterBounds := sm.boundsIdxSearch(lowerBound, upperBound)
+	if iterBounds == nil {
+		return nil, errors.New(noValuesErr)
+	}
+
+	var buckets []Bucket
+	for i := iterBounds[0]; i <= iterBounds[1]; i++ {
+		rec := Record{Key: sm.sorted[i], Val: sm.idx[sm.sorted[i]]}
+		bound := bucketFn(rec.Val)
+
+		last := len(buckets) - 1
+		if last < 0 || sm.cmpFn(buckets[last].Bound, bound) != 0 {
+			buckets = append(buckets, Bucket{Bound: bound, Result: reduceFn(nil, rec)})
+			continue
+		}
+		buckets[last].Result = reduceFn(buckets[last].Result, rec)
+	}
+	return buckets, nil
+}
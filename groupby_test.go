@@ -0,0 +1,93 @@
+package sortedmap
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func countRecords(acc interface{}, _ Record) interface{} {
+	if acc == nil {
+		return 1
+	}
+	return acc.(int) + 1
+}
+
+func TestGroupBy(t *testing.T) {
+	sm := New(0, asc.Int)
+	for i, val := range []int{-7, -1, 0, 3, 9, 10, 11, 25} {
+		sm.Insert(i, val)
+	}
+
+	buckets, err := sm.GroupBy(nil, nil, IntBucket(10), countRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Bucket{{-10, 2}, {0, 3}, {10, 2}, {20, 1}}
+	if len(buckets) != len(expected) {
+		t.Fatalf("TestGroupBy failed: expected %v buckets, got %+v.", len(expected), buckets)
+	}
+	for i := range buckets {
+		if buckets[i] != expected[i] {
+			t.Fatalf("TestGroupBy failed: expected %+v, got %+v.", expected, buckets)
+		}
+	}
+
+	if _, err := sm.GroupBy(100, nil, IntBucket(10), countRecords); err == nil {
+		t.Fatal("TestGroupBy failed: no error was returned for an empty range.")
+	}
+}
+
+func TestGroupByTime(t *testing.T) {
+	start := time.Date(2018, 7, 6, 21, 0, 0, 0, time.UTC)
+	sm := New(0, asc.Time)
+	for i := 0; i < 180; i++ {
+		sm.Insert(i, start.Add(time.Duration(i)*20*time.Second))
+	}
+
+	buckets, err := sm.GroupBy(nil, nil, TimeBucket(time.Minute), countRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 60 {
+		t.Fatalf("TestGroupByTime failed: expected 60 buckets, got %v.", len(buckets))
+	}
+	for i, b := range buckets {
+		if b.Result != 3 || !b.Bound.(time.Time).Equal(start.Add(time.Duration(i)*time.Minute)) {
+			t.Fatalf("TestGroupByTime failed: unexpected bucket %v: %+v", i, b)
+		}
+	}
+}
+
+func TestNumericBuckets(t *testing.T) {
+	if b := Int64Bucket(5)(int64(-3)); b != int64(-5) {
+		t.Fatalf("TestNumericBuckets failed: Int64Bucket returned %v.", b)
+	}
+	if b := Float64Bucket(0.5)(1.7); b != 1.5 {
+		t.Fatalf("TestNumericBuckets failed: Float64Bucket returned %v.", b)
+	}
+	if b := IntBucket(10)(-10); b != -10 {
+		t.Fatalf("TestNumericBuckets failed: IntBucket returned %v.", b)
+	}
+}
+
+func TestInvalidBucketWidths(t *testing.T) {
+	for name, newBucketFunc := range map[string]func(){
+		"IntBucket(0)":        func() { IntBucket(0) },
+		"Int64Bucket(-1)":     func() { Int64Bucket(-1) },
+		"Float64Bucket(0)":    func() { Float64Bucket(0) },
+		"Float64Bucket(NaN)":  func() { Float64Bucket(math.NaN()) },
+		"Float64Bucket(+Inf)": func() { Float64Bucket(math.Inf(1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("TestInvalidBucketWidths failed: %v did not panic.", name)
+				}
+			}()
+			newBucketFunc()
+		}()
+	}
+}
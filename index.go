@@ -0,0 +1,155 @@
+package sortedmap
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Index is a secondary ordering of the records of a SortedMap, created by AddIndex.
+// Each Index keeps its own slice of sorted keys, while sharing the map of keys to values with its SortedMap.
+// Indexes are kept up to date as the SortedMap is modified, so inserts, replaces and deletes take O(n) time for each index.
+type Index struct {
+	name   string
+	sorted []interface{}
+	lessFn ComparisonFunc
+	sm     *SortedMap
+}
+
+func (sm *SortedMap) sortedCopy(lessFn ComparisonFunc) []interface{} {
+	sorted := make([]interface{}, len(sm.sorted), cap(sm.sorted))
+	copy(sorted, sm.sorted)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return lessFn(sm.idx[sorted[i]], sm.idx[sorted[j]])
+	})
+	return sorted
+}
+
+func (sm *SortedMap) insertIntoIndexes(key, val interface{}) {
+	for _, ix := range sm.indexes {
+		ix.sorted = sm.insertSortKey(ix.sorted, ix.lessFn, key, val)
+	}
+}
+
+// deleteFromIndexes must be called before the key is removed from the map of keys to values.
+func (sm *SortedMap) deleteFromIndexes(key, val interface{}) {
+	for _, ix := range sm.indexes {
+		ix.sorted = deleteInterface(ix.sorted, sm.keyIdx(ix.sorted, ix.lessFn, key, val))
+	}
+}
+
+func (sm *SortedMap) rebuildIndexes() {
+	for _, ix := range sm.indexes {
+		ix.sorted = sm.sortedCopy(ix.lessFn)
+	}
+}
+
+// AddIndex registers a secondary ordering of the collection, using the given comparison function.
+// The index is built in O(n log n) time and can be retrieved by name using the Index method.
+func (sm *SortedMap) AddIndex(name string, cmpFn ComparisonFunc) error {
+	if _, ok := sm.indexes[name]; ok {
+		return fmt.Errorf("Index already exists: %v", name)
+	}
+	if sm.indexes == nil {
+		sm.indexes = make(map[string]*Index)
+	}
+
+	lessFn := setComparisonFunc(cmpFn)
+	sm.indexes[name] = &Index{
+		name:   name,
+		sorted: sm.sortedCopy(lessFn),
+		lessFn: lessFn,
+		sm:     sm,
+	}
+	return nil
+}
+
+// RemoveIndex removes the index with the given name and returns true if it existed.
+func (sm *SortedMap) RemoveIndex(name string) bool {
+	if _, ok := sm.indexes[name]; ok {
+		delete(sm.indexes, name)
+		return true
+	}
+	return false
+}
+
+// Index returns the index with the given name, if it exists.
+func (sm *SortedMap) Index(name string) (*Index, bool) {
+	ix, ok := sm.indexes[name]
+	return ix, ok
+}
+
+// view returns a SortedMap value that uses the index's sorted keys, so that read methods can be shared.
+func (ix *Index) view() *SortedMap {
+	return &SortedMap{
+		idx:    ix.sm.idx,
+		sorted: ix.sorted,
+		lessFn: ix.lessFn,
+		cmpFn:  compareFromLess(ix.lessFn),
+	}
+}
+
+// Name returns the name that the index was registered with.
+func (ix *Index) Name() string {
+	return ix.name
+}
+
+// Len returns the number of items in the index.
+func (ix *Index) Len() int {
+	return len(ix.sorted)
+}
+
+// Keys returns a slice containing keys sorted by the index.
+// The returned slice is valid until the next modification to the SortedMap structure.
+func (ix *Index) Keys() []interface{} {
+	return ix.view().Keys()
+}
+
+// BoundedKeys returns a slice containing keys sorted by the index, with values between the given bounds, in the same way as SortedMap.BoundedKeys.
+// The returned slice is valid until the next modification to the SortedMap structure.
+func (ix *Index) BoundedKeys(lowerBound, upperBound interface{}) ([]interface{}, error) {
+	return ix.view().BoundedKeys(lowerBound, upperBound)
+}
+
+// IterCh returns a channel that records sorted by the index can be read from and processed.
+func (ix *Index) IterCh() (IterChCloser, error) {
+	return ix.view().IterCh()
+}
+
+// BoundedIterCh returns a channel that records sorted by the index can be read from and processed.
+// Sort order is reversed if the reversed argument is set to true.
+func (ix *Index) BoundedIterCh(reversed bool, lowerBound, upperBound interface{}) (IterChCloser, error) {
+	return ix.view().BoundedIterCh(reversed, lowerBound, upperBound)
+}
+
+// CustomIterCh returns a channel that records sorted by the index can be read from and processed, using the given settings.
+func (ix *Index) CustomIterCh(params IterChParams) (IterChCloser, error) {
+	return ix.view().CustomIterCh(params)
+}
+
+// IterFunc passes each record to the specified callback function, in the order of the index.
+// Sort order is reversed if the reversed argument is set to true.
+func (ix *Index) IterFunc(reversed bool, f IterCallbackFunc) {
+	ix.view().IterFunc(reversed, f)
+}
+
+// BoundedIterFunc passes each record with a value between the given bounds to the callback function, in the order of the index.
+// Sort order is reversed if the reversed argument is set to true.
+func (ix *Index) BoundedIterFunc(reversed bool, lowerBound, upperBound interface{}, f IterCallbackFunc) error {
+	return ix.view().BoundedIterFunc(reversed, lowerBound, upperBound, f)
+}
+
+// EqualRange returns the keys that are mapped to values the index considers equal to val, in the order of the index.
+func (ix *Index) EqualRange(val interface{}) []interface{} {
+	return ix.view().EqualRange(val)
+}
+
+// CountEqual returns the number of values that the index considers equal to val, in O(log n) time.
+func (ix *Index) CountEqual(val interface{}) int {
+	return ix.view().CountEqual(val)
+}
+
+// FirstWithValue returns the first key in the order of the index that is mapped to a value the index considers equal to val.
+func (ix *Index) FirstWithValue(val interface{}) (interface{}, bool) {
+	return ix.view().FirstWithValue(val)
+}
@@ -0,0 +1,188 @@
+package sortedmap
+
+import (
+	"errors"
+	"fmt"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+type indexTestVal struct {
+	Created  time.Time
+	Priority int
+}
+
+func indexTestCreated(i, j interface{}) bool {
+	return asc.Time(i.(indexTestVal).Created, j.(indexTestVal).Created)
+}
+
+func indexTestPriority(i, j interface{}) bool {
+	return desc.Int(i.(indexTestVal).Priority, j.(indexTestVal).Priority)
+}
+
+func newIndexTestMap(t *testing.T, n int) (*SortedMap, []Record) {
+	records := randRecords(n)
+	for i := range records {
+		records[i].Val = indexTestVal{
+			Created:  records[i].Val.(time.Time),
+			Priority: mrand.Intn(10),
+		}
+	}
+
+	sm := New(n, indexTestCreated)
+	sm.BatchInsert(records[:n/2])
+	if err := sm.AddIndex("priority", indexTestPriority); err != nil {
+		t.Fatal(err)
+	}
+	sm.BatchInsert(records[n/2:])
+
+	return sm, records
+}
+
+func verifyIndex(sm *SortedMap, name string, lessFn ComparisonFunc) error {
+	ix, ok := sm.Index(name)
+	if !ok {
+		return fmt.Errorf("index not found: %v", name)
+	}
+	if ix.Len() != sm.Len() {
+		return fmt.Errorf("index length mismatch. Expected: %v, Had: %v.", sm.Len(), ix.Len())
+	}
+	keys := ix.Keys()
+	for i, key := range keys {
+		if !sm.Has(key) {
+			return fmt.Errorf("%v: %v", notFoundErr, key)
+		}
+		if i > 0 && lessFn(sm.idx[key], sm.idx[keys[i-1]]) {
+			return errors.New(unsortedErr)
+		}
+	}
+	return nil
+}
+
+func TestAddIndex(t *testing.T) {
+	sm, records := newIndexTestMap(t, 300)
+
+	if err := verifyIndex(sm, "priority", indexTestPriority); err != nil {
+		t.Fatalf("TestAddIndex failed: %v", err)
+	}
+	if err := sm.AddIndex("priority", indexTestPriority); err == nil {
+		t.Fatal("TestAddIndex failed: a duplicate index was added.")
+	}
+
+	for _, rec := range records[:50] {
+		sm.Delete(rec.Key)
+	}
+	for _, rec := range records[50:100] {
+		sm.Replace(rec.Key, indexTestVal{Created: maxTime, Priority: 5})
+	}
+	if err := sm.BoundedDelete(nil, indexTestVal{Created: time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyIndex(sm, "priority", indexTestPriority); err != nil {
+		t.Fatalf("TestAddIndex failed: %v", err)
+	}
+
+	if !sm.RemoveIndex("priority") {
+		t.Fatal("TestAddIndex failed: the index was not removed.")
+	}
+	if sm.RemoveIndex("priority") {
+		t.Fatal("TestAddIndex failed: a missing index was removed.")
+	}
+	if _, ok := sm.Index("priority"); ok {
+		t.Fatal("TestAddIndex failed: a removed index was found.")
+	}
+}
+
+func TestIndexIteration(t *testing.T) {
+	sm, _ := newIndexTestMap(t, 300)
+
+	ix, ok := sm.Index("priority")
+	if !ok || ix.Name() != "priority" {
+		t.Fatal("TestIndexIteration failed: index not found.")
+	}
+
+	lowerBound, upperBound := indexTestVal{Priority: 7}, indexTestVal{Priority: 3}
+
+	count := 0
+	if err := ix.BoundedIterFunc(false, lowerBound, upperBound, func(rec Record) bool {
+		if p := rec.Val.(indexTestVal).Priority; p > 7 || p < 3 {
+			t.Fatalf("TestIndexIteration failed: %v: %v", generalBoundsErr, p)
+		}
+		count++
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := ix.BoundedKeys(lowerBound, upperBound)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != count {
+		t.Fatalf("TestIndexIteration failed: Expected: %v keys, Had: %v.", count, len(keys))
+	}
+
+	prev := 10
+	ix.IterFunc(false, func(rec Record) bool {
+		if p := rec.Val.(indexTestVal).Priority; p > prev {
+			t.Fatalf("TestIndexIteration failed: %v", unsortedErr)
+		} else {
+			prev = p
+		}
+		return true
+	})
+
+	for _, params := range []IterChParams{{}, {Reversed: true, LowerBound: lowerBound, UpperBound: upperBound}} {
+		iterCh, err := ix.CustomIterCh(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for range iterCh.Records() {
+			n++
+		}
+		iterCh.Close()
+
+		if params.LowerBound != nil && n != count {
+			t.Fatalf("TestIndexIteration failed: Expected: %v records, Had: %v.", count, n)
+		}
+	}
+
+	if iterCh, err := ix.IterCh(); err != nil {
+		t.Fatal(err)
+	} else {
+		iterCh.Close()
+	}
+	if iterCh, err := ix.BoundedIterCh(true, lowerBound, upperBound); err != nil {
+		t.Fatal(err)
+	} else {
+		iterCh.Close()
+	}
+}
+
+func TestIndexWithSetRecords(t *testing.T) {
+	sm, _ := newIndexTestMap(t, 300)
+	other, _ := newIndexTestMap(t, 100)
+
+	sm.MergeFrom(other, nil)
+	if err := verifyIndex(sm, "priority", indexTestPriority); err != nil {
+		t.Fatalf("TestIndexWithSetRecords failed: %v", err)
+	}
+
+	clone := sm.Clone()
+	keys := append([]interface{}(nil), clone.Keys()[:100]...)
+	for _, key := range keys {
+		clone.Delete(key)
+	}
+	if err := verifyIndex(clone, "priority", indexTestPriority); err != nil {
+		t.Fatalf("TestIndexWithSetRecords failed: %v", err)
+	}
+	if err := verifyIndex(sm, "priority", indexTestPriority); err != nil {
+		t.Fatalf("TestIndexWithSetRecords failed: %v", err)
+	}
+}
@@ -5,17 +5,34 @@ import (
 	"fmt"
 )
 
-func (sm *SortedMap) insert(key, val interface{}) bool {
+func (sm *SortedMap) insertRecord(key, val interface{}) bool {
 	if _, ok := sm.idx[key]; !ok {
 		sm.idx[key] = val
-		sm.sorted = sm.insertSort(key, val)
+		i := sm.insertSortIdx(sm.sorted, sm.lessFn, val)
+		sm.sorted = insertInterface(sm.sorted, key, i)
+		sm.insertAggregate(i, val)
+		sm.insertIntoIndexes(key, val)
+		sm.insertIntoKeyOrder(key)
+		return true
+	}
+	return false
+}
+
+func (sm *SortedMap) insert(key, val interface{}) bool {
+	if sm.rejects(key, val) {
+		return false
+	}
+	if sm.insertRecord(key, val) {
+		sm.updateAggregate()
+		sm.wal.logInsert(key, val)
+		sm.checkOrder("Insert")
 		return true
 	}
 	return false
 }
 
 // Insert uses the provided 'less than' function to insert sort and add the value to the collection and returns a value containing the record's insert status.
-// If the key already exists, the value will not be inserted. Use Replace for the alternative functionality.
+// If the key already exists, or the value is rejected by an Option, the value will not be inserted. Use Replace for the alternative functionality.
 func (sm *SortedMap) Insert(key, val interface{}) bool {
 	return sm.insert(key, val)
 }
@@ -30,10 +47,17 @@ func (sm *SortedMap) BatchInsert(recs []Record) []bool {
 	return results
 }
 
+func (sm *SortedMap) insertErr(key, val interface{}) error {
+	if sm.rejects(key, val) {
+		return fmt.Errorf(rejectedValErr, key)
+	}
+	return fmt.Errorf("Key already exists: %+v", key)
+}
+
 func (sm *SortedMap) batchInsertMapInterfaceKeys(m map[interface{}]interface{}) error {
 	for key, val := range m {
 		if !sm.insert(key, val) {
-			return fmt.Errorf("Key already exists: %+v", key)
+			return sm.insertErr(key, val)
 		}
 	}
 	return nil
@@ -42,7 +66,7 @@ func (sm *SortedMap) batchInsertMapInterfaceKeys(m map[interface{}]interface{})
 func (sm *SortedMap) batchInsertMapStringKeys(m map[string]interface{}) error {
 	for key, val := range m {
 		if !sm.insert(key, val) {
-			return fmt.Errorf("Key already exists: %+v", key)
+			return sm.insertErr(key, val)
 		}
 	}
 	return nil
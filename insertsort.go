@@ -2,8 +2,13 @@ package sortedmap
 
 import "sort"
 
-func (sm *SortedMap) insertSort(key, val interface{}) []interface{} {
-	return insertInterface(sm.sorted, key, sort.Search(len(sm.sorted), func(i int) bool {
-		return sm.lessFn(val, sm.idx[sm.sorted[i]])
-	}))
+// insertSortIdx returns the index that a key with val is inserted at, after any keys with equal values.
+func (sm *SortedMap) insertSortIdx(sorted []interface{}, lessFn ComparisonFunc, val interface{}) int {
+	return sort.Search(len(sorted), func(i int) bool {
+		return lessFn(val, sm.idx[sorted[i]])
+	})
+}
+
+func (sm *SortedMap) insertSortKey(sorted []interface{}, lessFn ComparisonFunc, key, val interface{}) []interface{} {
+	return insertInterface(sorted, key, sm.insertSortIdx(sorted, lessFn, val))
 }
@@ -0,0 +1,205 @@
+package sortedmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// DecodeFunc defines the type of function used to convert encoded data back into a key or value.
+type DecodeFunc func(data []byte) (interface{}, error)
+
+type jsonRecord struct {
+	Key json.RawMessage `json:"key"`
+	Val json.RawMessage `json:"val"`
+}
+
+func decodeJSONInterface(data []byte) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func setDecodeFunc(fn DecodeFunc) DecodeFunc {
+	if fn == nil {
+		return decodeJSONInterface
+	}
+	return fn
+}
+
+func (sm *SortedMap) hasStringKeys() bool {
+	for _, key := range sm.sorted {
+		if _, ok := key.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSONPair(w *bufio.Writer, key, val interface{}, sep []byte) error {
+	keyData, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	valData, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	w.Write(keyData)
+	w.Write(sep)
+	w.Write(valData)
+
+	return nil
+}
+
+// SetJSONDecoders sets the functions that UnmarshalJSON uses to convert raw JSON keys and values into stored types.
+// Each function is passed the raw JSON of a single key or value. Keys of the object form are passed as JSON strings.
+// A nil function decodes into the default encoding/json types, e.g. float64 for numbers.
+func (sm *SortedMap) SetJSONDecoders(keyFn, valFn DecodeFunc) {
+	sm.jsonKeyFn = keyFn
+	sm.jsonValFn = valFn
+}
+
+// EncodeJSON writes the collection to w as JSON, in sorted order, without building the whole document in memory.
+// When every key is a string, the records are written as an object with ordered members.
+// Otherwise, they are written as an ordered array of {"key":…,"val":…} objects.
+func (sm *SortedMap) EncodeJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	start, end, sep := "[", "]", []byte(`,"val":`)
+	stringKeys := sm.hasStringKeys()
+	if stringKeys {
+		start, end, sep = "{", "}", []byte(":")
+	}
+
+	bw.WriteString(start)
+	for i, key := range sm.sorted {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		if !stringKeys {
+			bw.WriteString(`{"key":`)
+		}
+		if err := writeJSONPair(bw, key, sm.idx[key], sep); err != nil {
+			return err
+		}
+		if !stringKeys {
+			bw.WriteByte('}')
+		}
+	}
+	bw.WriteString(end)
+
+	return bw.Flush()
+}
+
+// MarshalJSON implements the json.Marshaler interface, using the format written by EncodeJSON.
+func (sm *SortedMap) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := sm.EncodeJSON(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeJSONRecord converts the raw JSON of a key and a value into a record.
+// An error is returned if the decoded key has a type that cannot be used as a map key, such as a slice.
+func decodeJSONRecord(keyData, valData []byte, keyFn, valFn DecodeFunc) (Record, error) {
+	var err error
+	rec := Record{}
+	if rec.Key, err = keyFn(keyData); err != nil {
+		return rec, err
+	}
+	if rec.Key != nil && !reflect.TypeOf(rec.Key).Comparable() {
+		return rec, fmt.Errorf(uncomparableKeyErr, rec.Key)
+	}
+	if rec.Val, err = valFn(valData); err != nil {
+		return rec, err
+	}
+	return rec, nil
+}
+
+func (sm *SortedMap) decodeJSONObject(dec *json.Decoder, keyFn, valFn DecodeFunc) ([]Record, error) {
+	recs := make([]Record, 0)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keyData, err := json.Marshal(tok)
+		if err != nil {
+			return nil, err
+		}
+		var valData json.RawMessage
+		if err := dec.Decode(&valData); err != nil {
+			return nil, err
+		}
+
+		rec, err := decodeJSONRecord(keyData, valData, keyFn, valFn)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+func (sm *SortedMap) decodeJSONArray(dec *json.Decoder, keyFn, valFn DecodeFunc) ([]Record, error) {
+	recs := make([]Record, 0)
+	for dec.More() {
+		var jsonRec jsonRecord
+		if err := dec.Decode(&jsonRec); err != nil {
+			return nil, err
+		}
+
+		rec, err := decodeJSONRecord(jsonRec.Key, jsonRec.Val, keyFn, valFn)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface and accepts both formats written by EncodeJSON.
+// Keys and values are converted using the functions given to SetJSONDecoders and are sorted
+// using the comparison function of the receiver, which replaces any existing contents.
+func (sm *SortedMap) UnmarshalJSON(data []byte) error {
+	const invalidJSONErr = "JSON data must be an object or an array of records."
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	keyFn, valFn := setDecodeFunc(sm.jsonKeyFn), setDecodeFunc(sm.jsonValFn)
+
+	var recs []Record
+	switch tok {
+	case json.Delim('{'):
+		recs, err = sm.decodeJSONObject(dec, keyFn, valFn)
+
+	case json.Delim('['):
+		recs, err = sm.decodeJSONArray(dec, keyFn, valFn)
+
+	default:
+		return errors.New(invalidJSONErr)
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to decode JSON record: %v", err)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	lessFn := setComparisonFunc(sm.lessFn)
+	sort.SliceStable(recs, func(i, j int) bool {
+		return lessFn(recs[i].Val, recs[j].Val)
+	})
+	return sm.loadSorted(recs)
+}
@@ -0,0 +1,171 @@
+package sortedmap
+
+import (
+	"bytes"
+	"encoding/json"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func decodeJSONTime(data []byte) (interface{}, error) {
+	var t time.Time
+	err := json.Unmarshal(data, &t)
+	return t, err
+}
+
+func decodeJSONInt(data []byte) (interface{}, error) {
+	var i int
+	err := json.Unmarshal(data, &i)
+	return i, err
+}
+
+func TestMarshalJSONStringKeys(t *testing.T) {
+	sm := New(3, asc.Int)
+	sm.Insert("c", 1)
+	sm.Insert("a", 3)
+	sm.Insert("b", 2)
+
+	data, err := json.Marshal(sm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `{"c":1,"b":2,"a":3}`
+	if string(data) != expected {
+		t.Fatalf("TestMarshalJSONStringKeys failed: Expected: %v, Had: %v.", expected, string(data))
+	}
+}
+
+func TestMarshalJSONNonStringKeys(t *testing.T) {
+	sm := New(3, desc.Int)
+	sm.Insert(1, 1)
+	sm.Insert("a", 3)
+	sm.Insert(2.5, 2)
+
+	data, err := json.Marshal(sm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `[{"key":"a","val":3},{"key":2.5,"val":2},{"key":1,"val":1}]`
+	if string(data) != expected {
+		t.Fatalf("TestMarshalJSONNonStringKeys failed: Expected: %v, Had: %v.", expected, string(data))
+	}
+}
+
+func TestMarshalJSONEmpty(t *testing.T) {
+	data, err := json.Marshal(New(0, asc.Int))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{}" {
+		t.Fatalf("TestMarshalJSONEmpty failed: Expected: {}, Had: %v.", string(data))
+	}
+}
+
+func TestMarshalJSONUnsupportedValue(t *testing.T) {
+	sm := New(1, nil)
+	sm.Insert("a", make(chan int))
+
+	if _, err := json.Marshal(sm); err == nil {
+		t.Fatal("TestMarshalJSONUnsupportedValue failed: an unsupported value type was encoded.")
+	}
+}
+
+func TestJSONRoundTripStringKeys(t *testing.T) {
+	sm, _, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := sm.EncodeJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := New(0, asc.Time)
+	decoded.SetJSONDecoders(nil, decodeJSONTime)
+	if err := json.Unmarshal(buf.Bytes(), decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(sm, decoded); err != nil {
+		t.Fatalf("TestJSONRoundTripStringKeys failed: %v", err)
+	}
+}
+
+func TestJSONRoundTripNonStringKeys(t *testing.T) {
+	sm := New(100, desc.Int)
+	for i := 0; i < 100; i++ {
+		sm.Insert(i, mrand.Intn(1000))
+	}
+
+	data, err := json.Marshal(sm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := New(0, desc.Int)
+	decoded.SetJSONDecoders(decodeJSONInt, decodeJSONInt)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(sm, decoded); err != nil {
+		t.Fatalf("TestJSONRoundTripNonStringKeys failed: %v", err)
+	}
+}
+
+func TestUnmarshalJSONSortsRecords(t *testing.T) {
+	sm := New(0, asc.Float64)
+	if err := json.Unmarshal([]byte(`{"a":3,"b":1,"c":2}`), sm); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []interface{}{"b", "c", "a"}
+	for i, key := range sm.Keys() {
+		if key != expected[i] {
+			t.Fatalf("TestUnmarshalJSONSortsRecords failed: %v", unsortedErr)
+		}
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	sm := New(0, asc.Int)
+	sm.SetJSONDecoders(decodeJSONInt, decodeJSONInt)
+
+	for _, data := range []string{
+		``,
+		`1`,
+		`{"a":1`,
+		`[{"key":1,"val":"a"}]`,
+		`[{"key":"a","val":1}]`,
+		`{"a":"b"}`,
+		`[{"key":1,"val":1},{"key":1,"val":2}]`,
+		`[1]`,
+	} {
+		if err := sm.UnmarshalJSON([]byte(data)); err == nil {
+			t.Fatalf("TestUnmarshalJSONErrors failed: invalid data was accepted: %v", data)
+		}
+	}
+}
+
+func TestUnmarshalJSONUncomparableKeys(t *testing.T) {
+	sm := New(0, asc.Float64)
+
+	for _, data := range []string{
+		`[{"key":[1],"val":1}]`,
+		`[{"key":{"a":1},"val":1}]`,
+	} {
+		if err := sm.UnmarshalJSON([]byte(data)); err == nil {
+			t.Fatalf("TestUnmarshalJSONUncomparableKeys failed: an uncomparable key was accepted: %v", data)
+		}
+	}
+
+	sm.SetJSONDecoders(func(data []byte) (interface{}, error) {
+		return []byte(data), nil
+	}, nil)
+	if err := sm.UnmarshalJSON([]byte(`{"a":1}`)); err == nil {
+		t.Fatal("TestUnmarshalJSONUncomparableKeys failed: an uncomparable key from the object form was accepted.")
+	}
+}
@@ -0,0 +1,167 @@
+package sortedmap
+
+import (
+	"errors"
+	"sort"
+)
+
+const noKeyOrderErr = "Key ordering is disabled. Use SetKeyComparisonFunc to enable it."
+
+// SetKeyComparisonFunc enables a second ordering of the collection by key, using the given comparison function for keys.
+// The key ordering is built in O(n log n) time and is kept up to date as the collection is modified.
+// A nil function disables the key ordering.
+func (sm *SortedMap) SetKeyComparisonFunc(cmpFn ComparisonFunc) {
+	sm.keyLessFn = cmpFn
+	sm.rebuildKeyOrder()
+}
+
+func (sm *SortedMap) rebuildKeyOrder() {
+	if sm.keyLessFn == nil {
+		sm.keySorted = nil
+		return
+	}
+	sm.keySorted = make([]interface{}, len(sm.sorted), cap(sm.sorted))
+	copy(sm.keySorted, sm.sorted)
+
+	sort.Slice(sm.keySorted, func(i, j int) bool {
+		return sm.keyLessFn(sm.keySorted[i], sm.keySorted[j])
+	})
+}
+
+// setKeyIdx returns the index position of the first key that is not less than the given key.
+func (sm *SortedMap) setKeyIdx(key interface{}) int {
+	return sort.Search(len(sm.keySorted), func(i int) bool {
+		return !sm.keyLessFn(sm.keySorted[i], key)
+	})
+}
+
+func (sm *SortedMap) insertIntoKeyOrder(key interface{}) {
+	if sm.keyLessFn != nil {
+		sm.keySorted = insertInterface(sm.keySorted, key, sm.setKeyIdx(key))
+	}
+}
+
+// keyOrderIdx returns the index position of key within the key order.
+// The search starts at the first key that compares equal, so keys that the key comparison function considers equal are skipped.
+func (sm *SortedMap) keyOrderIdx(key interface{}) int {
+	i := sm.setKeyIdx(key)
+	for i < len(sm.keySorted) && sm.keySorted[i] != key {
+		i++
+	}
+	if i == len(sm.keySorted) {
+		return sm.linearKeyIdx(sm.keySorted, key)
+	}
+	return i
+}
+
+func (sm *SortedMap) deleteFromKeyOrder(key interface{}) {
+	if sm.keyLessFn != nil {
+		sm.keySorted = deleteInterface(sm.keySorted, sm.keyOrderIdx(key))
+	}
+}
+
+func (sm *SortedMap) keyBoundsIdxSearch(lowerKey, upperKey interface{}) []int {
+	if len(sm.keySorted) == 0 {
+		return nil
+	}
+	if lowerKey != nil && upperKey != nil && sm.keyLessFn(upperKey, lowerKey) {
+		return nil
+	}
+
+	lowerIdx := 0
+	if lowerKey != nil {
+		lowerIdx = sm.setKeyIdx(lowerKey)
+	}
+
+	upperIdx := len(sm.keySorted) - 1
+	if upperKey != nil {
+		upperIdx = sort.Search(len(sm.keySorted), func(i int) bool {
+			return sm.keyLessFn(upperKey, sm.keySorted[i])
+		}) - 1
+	}
+
+	if lowerIdx > upperIdx {
+		return nil
+	}
+	return []int{
+		lowerIdx,
+		upperIdx,
+	}
+}
+
+// KeyRange returns a slice containing keys, sorted by key, that are equal to or between the given keys.
+// A nil key leaves that end of the range unbounded.
+// The returned slice is valid until the next modification to the SortedMap structure.
+func (sm *SortedMap) KeyRange(lowerKey, upperKey interface{}) ([]interface{}, error) {
+	if sm.keyLessFn == nil {
+		return nil, errors.New(noKeyOrderErr)
+	}
+	idxBounds := sm.keyBoundsIdxSearch(lowerKey, upperKey)
+	if idxBounds == nil {
+		return nil, errors.New(noValuesErr)
+	}
+	return sm.keySorted[idxBounds[0] : idxBounds[1]+1], nil
+}
+
+// KeyIterFunc passes each record with a key equal to or between the given keys to the callback function, sorted by key.
+// A nil key leaves that end of the range unbounded.
+// Sort order is reversed if the reversed argument is set to true.
+func (sm *SortedMap) KeyIterFunc(reversed bool, lowerKey, upperKey interface{}, f IterCallbackFunc) error {
+	keys, err := sm.KeyRange(lowerKey, upperKey)
+	if err != nil {
+		return err
+	}
+
+	if reversed {
+		for i := len(keys) - 1; i >= 0; i-- {
+			if !f(Record{Key: keys[i], Val: sm.idx[keys[i]]}) {
+				break
+			}
+		}
+	} else {
+		for _, key := range keys {
+			if !f(Record{Key: key, Val: sm.idx[key]}) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// FloorKey returns the greatest key that is less than or equal to the given key.
+func (sm *SortedMap) FloorKey(key interface{}) (interface{}, bool) {
+	if sm.keyLessFn == nil {
+		return nil, false
+	}
+	i := sm.setKeyIdx(key)
+	if i < len(sm.keySorted) && !sm.keyLessFn(key, sm.keySorted[i]) {
+		return sm.keySorted[i], true
+	}
+	if i == 0 {
+		return nil, false
+	}
+	return sm.keySorted[i-1], true
+}
+
+// CeilingKey returns the least key that is greater than or equal to the given key.
+func (sm *SortedMap) CeilingKey(key interface{}) (interface{}, bool) {
+	if sm.keyLessFn == nil {
+		return nil, false
+	}
+	i := sm.setKeyIdx(key)
+	if i == len(sm.keySorted) {
+		return nil, false
+	}
+	return sm.keySorted[i], true
+}
+
+// KeyRank returns the number of keys that are less than the given key and whether the key exists in the collection.
+// KeyRank returns -1 and false if key ordering is disabled.
+func (sm *SortedMap) KeyRank(key interface{}) (int, bool) {
+	if sm.keyLessFn == nil {
+		return -1, false
+	}
+	_, ok := sm.idx[key]
+	return sm.setKeyIdx(key), ok
+}
@@ -0,0 +1,209 @@
+package sortedmap
+
+import (
+	"fmt"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func stringLess(i, j interface{}) bool {
+	return i.(string) < j.(string)
+}
+
+func newKeyOrderTestMap() *SortedMap {
+	sm := New(300, asc.Int)
+	for i := 0; i < 150; i++ {
+		sm.Insert(fmt.Sprintf("user:%03d", i*2), mrand.Intn(1000))
+	}
+	sm.SetKeyComparisonFunc(stringLess)
+	for i := 150; i < 300; i++ {
+		sm.Insert(fmt.Sprintf("user:%03d", i*2), mrand.Intn(1000))
+	}
+	return sm
+}
+
+func verifyKeyOrder(sm *SortedMap) error {
+	if len(sm.keySorted) != sm.Len() {
+		return fmt.Errorf("key order length mismatch. Expected: %v, Had: %v.", sm.Len(), len(sm.keySorted))
+	}
+	for i, key := range sm.keySorted {
+		if !sm.Has(key) {
+			return fmt.Errorf("%v: %v", notFoundErr, key)
+		}
+		if i > 0 && !stringLess(sm.keySorted[i-1], key) {
+			return fmt.Errorf("%v %v, %v", unsortedErr, sm.keySorted[i-1], key)
+		}
+	}
+	return nil
+}
+
+func TestKeyRange(t *testing.T) {
+	sm := newKeyOrderTestMap()
+	if err := verifyKeyOrder(sm); err != nil {
+		t.Fatalf("TestKeyRange failed: %v", err)
+	}
+
+	keys, err := sm.KeyRange("user:100", "user:200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 51 || keys[0] != "user:100" || keys[50] != "user:200" {
+		t.Fatalf("TestKeyRange failed: %v: %v", generalBoundsErr, keys)
+	}
+
+	keys, err = sm.KeyRange("user:099", "user:101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != "user:100" {
+		t.Fatalf("TestKeyRange failed: %v: %v", generalBoundsErr, keys)
+	}
+
+	if keys, err := sm.KeyRange(nil, nil); err != nil || len(keys) != sm.Len() {
+		t.Fatalf("TestKeyRange failed: %v: %v", nilBoundValsErr, err)
+	}
+	if _, err := sm.KeyRange("user:101", "user:101"); err == nil {
+		t.Fatal("TestKeyRange failed: a range without keys was accepted.")
+	}
+	if _, err := sm.KeyRange("user:200", "user:100"); err == nil {
+		t.Fatal("TestKeyRange failed: reversed bounds were accepted.")
+	}
+	if _, err := New(0, asc.Int).KeyRange(nil, nil); err == nil {
+		t.Fatal("TestKeyRange failed: disabled key ordering was accepted.")
+	}
+}
+
+func TestKeyOrderModifications(t *testing.T) {
+	sm := newKeyOrderTestMap()
+
+	for i := 0; i < 100; i++ {
+		sm.Delete(fmt.Sprintf("user:%03d", i*4))
+		sm.Replace(fmt.Sprintf("user:%03d", i*3), i)
+	}
+	if err := sm.BoundedDelete(100, 500); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyKeyOrder(sm); err != nil {
+		t.Fatalf("TestKeyOrderModifications failed: %v", err)
+	}
+
+	sm.MergeFrom(newKeyOrderTestMap(), nil)
+	if err := verifyKeyOrder(sm); err != nil {
+		t.Fatalf("TestKeyOrderModifications failed: %v", err)
+	}
+
+	clone := sm.Clone()
+	clone.Delete("user:100")
+	if err := verifyKeyOrder(clone); err != nil {
+		t.Fatalf("TestKeyOrderModifications failed: %v", err)
+	}
+	if err := verifyKeyOrder(sm); err != nil {
+		t.Fatalf("TestKeyOrderModifications failed: %v", err)
+	}
+
+	sm.SetKeyComparisonFunc(nil)
+	if sm.keySorted != nil {
+		t.Fatal("TestKeyOrderModifications failed: the key ordering was not disabled.")
+	}
+	sm.Insert("user:new", 1)
+}
+
+func TestKeyOrderTies(t *testing.T) {
+	sm := New(0, asc.Int)
+	sm.SetKeyComparisonFunc(asc.StringFold)
+	sm.Insert("a", 1)
+	sm.Insert("A", 2)
+	sm.Insert("b", 3)
+	sm.Insert("B", 4)
+
+	sm.Delete("a")
+	sm.Delete("B")
+
+	keys, err := sm.KeyRange(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != "A" || keys[1] != "b" {
+		t.Fatalf("TestKeyOrderTies failed: expected [A b], got %v.", keys)
+	}
+}
+
+func TestKeyIterFunc(t *testing.T) {
+	sm := newKeyOrderTestMap()
+
+	for _, reversed := range []bool{false, true} {
+		keys := make([]interface{}, 0)
+		if err := sm.KeyIterFunc(reversed, "user:100", "user:200", func(rec Record) bool {
+			if rec.Val != sm.idx[rec.Key] {
+				t.Fatalf("TestKeyIterFunc failed: invalid value for key %v.", rec.Key)
+			}
+			keys = append(keys, rec.Key)
+			return len(keys) < 10
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		first, last := "user:100", "user:118"
+		if reversed {
+			first, last = "user:200", "user:182"
+		}
+		if len(keys) != 10 || keys[0] != first || keys[9] != last {
+			t.Fatalf("TestKeyIterFunc failed: %v: %v", generalBoundsErr, keys)
+		}
+	}
+
+	if err := sm.KeyIterFunc(false, "user:999", nil, func(rec Record) bool {
+		return true
+	}); err == nil {
+		t.Fatal("TestKeyIterFunc failed: a range without keys was accepted.")
+	}
+}
+
+func TestFloorAndCeilingKeys(t *testing.T) {
+	sm := newKeyOrderTestMap()
+
+	tests := []struct {
+		key            string
+		floor, ceiling interface{}
+	}{
+		{"user:100", "user:100", "user:100"},
+		{"user:101", "user:100", "user:102"},
+		{"user:", nil, "user:000"},
+		{"user:999", "user:598", nil},
+	}
+
+	for _, test := range tests {
+		floor, ok := sm.FloorKey(test.key)
+		if floor != test.floor || ok != (test.floor != nil) {
+			t.Fatalf("TestFloorAndCeilingKeys failed: FloorKey(%v). Expected: %v, Had: %v.", test.key, test.floor, floor)
+		}
+		ceiling, ok := sm.CeilingKey(test.key)
+		if ceiling != test.ceiling || ok != (test.ceiling != nil) {
+			t.Fatalf("TestFloorAndCeilingKeys failed: CeilingKey(%v). Expected: %v, Had: %v.", test.key, test.ceiling, ceiling)
+		}
+	}
+
+	disabled := New(0, asc.Int)
+	if _, ok := disabled.FloorKey("a"); ok {
+		t.Fatal("TestFloorAndCeilingKeys failed: disabled key ordering was accepted.")
+	}
+	if _, ok := disabled.CeilingKey("a"); ok {
+		t.Fatal("TestFloorAndCeilingKeys failed: disabled key ordering was accepted.")
+	}
+}
+
+func TestKeyRank(t *testing.T) {
+	sm := newKeyOrderTestMap()
+
+	if rank, ok := sm.KeyRank("user:100"); rank != 50 || !ok {
+		t.Fatalf("TestKeyRank failed: Expected: 50, true, Had: %v, %v.", rank, ok)
+	}
+	if rank, ok := sm.KeyRank("user:101"); rank != 51 || ok {
+		t.Fatalf("TestKeyRank failed: Expected: 51, false, Had: %v, %v.", rank, ok)
+	}
+	if rank, ok := New(0, asc.Int).KeyRank("user:100"); rank != -1 || ok {
+		t.Fatalf("TestKeyRank failed: Expected: -1, false, Had: %v, %v.", rank, ok)
+	}
+}
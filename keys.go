@@ -17,7 +17,9 @@ func (sm *SortedMap) Keys() []interface{} {
 	return keys
 }
 
-// BoundedKeys returns a slice containing sorted keys equal to or between the given bounds.
+// BoundedKeys returns a slice containing sorted keys with values between the given bounds.
+// Values equal to the upper bound are included, while values equal to the lower bound are only included
+// when they are the greatest values in the collection.
 // The returned slice is valid until the next modification to the SortedMap structure.
 func (sm *SortedMap) BoundedKeys(lowerBound, upperBound interface{}) ([]interface{}, error) {
 	return sm.keys(lowerBound, upperBound)
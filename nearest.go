@@ -0,0 +1,33 @@
+package sortedmap
+
+// DistanceFunc defines the type of function used to measure the distance between two values.
+// Distances must not be negative, and must grow as values move apart in sorted order.
+type DistanceFunc func(i, j interface{}) float64
+
+// Nearest returns up to k records with the values closest to val, ordered by increasing distance, in O(log n + k) time.
+// The search starts at val's binary search position and expands outward in both directions,
+// so dist must be consistent with the comparison function. Records at equal distances are returned in sorted order.
+func (sm *SortedMap) Nearest(val interface{}, k int, dist DistanceFunc) []Record {
+	if k > len(sm.sorted) {
+		k = len(sm.sorted)
+	}
+	if k <= 0 {
+		return []Record{}
+	}
+
+	recs := make([]Record, 0, k)
+	upper := sm.lowerBoundIdx(val)
+	lower := upper - 1
+
+	for len(recs) < k {
+		i := upper
+		if upper == len(sm.sorted) || (lower >= 0 && dist(sm.idx[sm.sorted[lower]], val) <= dist(sm.idx[sm.sorted[upper]], val)) {
+			i = lower
+			lower--
+		} else {
+			upper++
+		}
+		recs = append(recs, Record{Key: sm.sorted[i], Val: sm.idx[sm.sorted[i]]})
+	}
+	return recs
+}
@@ -0,0 +1,61 @@
+package sortedmap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func TestNearest(t *testing.T) {
+	sm := New(0, asc.Int)
+	for i, val := range []int{10, 20, 30, 40, 50, 21} {
+		sm.Insert(i, val)
+	}
+
+	for _, c := range []struct {
+		val      int
+		k        int
+		expected []int
+	}{
+		{22, 3, []int{21, 20, 30}},
+		{25, 2, []int{21, 20}},
+		{0, 2, []int{10, 20}},
+		{100, 3, []int{50, 40, 30}},
+		{40, 1, []int{40}},
+		{35, 2, []int{30, 40}},
+		{30, 10, []int{30, 21, 20, 40, 10, 50}},
+	} {
+		recs := sm.Nearest(c.val, c.k, asc.IntDistance)
+		if len(recs) != len(c.expected) {
+			t.Fatalf("TestNearest failed: Nearest(%v, %v) returned %v records, expected %v.", c.val, c.k, len(recs), len(c.expected))
+		}
+		for i, rec := range recs {
+			if rec.Val != c.expected[i] || sm.idx[rec.Key] != rec.Val {
+				t.Fatalf("TestNearest failed: Nearest(%v, %v) returned %+v, expected %v.", c.val, c.k, recs, c.expected)
+			}
+		}
+	}
+
+	if len(sm.Nearest(0, 0, asc.IntDistance)) != 0 || len(New(0, asc.Int).Nearest(0, 3, asc.IntDistance)) != 0 {
+		t.Fatal("TestNearest failed: records were returned for an empty result.")
+	}
+}
+
+func TestNearestTime(t *testing.T) {
+	sm, records, err := newSortedMapFromRandRecords(500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := records[0].Val.(time.Time)
+
+	recs := sm.Nearest(target, 5, asc.TimeDistance)
+	if len(recs) != 5 || recs[0].Val != target {
+		t.Fatalf("TestNearestTime failed: unexpected records: %+v", recs)
+	}
+	for i := 1; i < len(recs); i++ {
+		if asc.TimeDistance(recs[i].Val, target) < asc.TimeDistance(recs[i-1].Val, target) {
+			t.Fatalf("TestNearestTime failed: records were not ordered by distance: %+v", recs)
+		}
+	}
+}
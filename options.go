@@ -0,0 +1,56 @@
+package sortedmap
+
+import "math"
+
+// Option defines the type of function used to configure a SortedMap when it is created by New or NewWithCompare.
+type Option func(*SortedMap)
+
+// RejectNaN returns an Option that prevents float32 and float64 NaN values from being added to the collection.
+// Insert returns false for a NaN value, Replace leaves the collection unchanged,
+// set operations skip the value, and BatchInsertMap and Apply return an error.
+func RejectNaN() Option {
+	return func(sm *SortedMap) {
+		sm.rejectNaN = true
+	}
+}
+
+func isNaN(val interface{}) bool {
+	switch v := val.(type) {
+	case float64:
+		return math.IsNaN(v)
+	case float32:
+		return math.IsNaN(float64(v))
+	}
+	return false
+}
+
+// UniqueValues returns an Option that prevents a value from being added to the collection
+// when an equal value is already held by another key, so that KeyOf can look up the key holding a value.
+// Insert returns false for a value that is already held, Replace leaves the collection unchanged,
+// set operations skip the value, and BatchInsertMap and Apply return an error.
+func UniqueValues() Option {
+	return func(sm *SortedMap) {
+		sm.uniqueVals = true
+	}
+}
+
+// rejectsVals reports whether the options given to New can prevent values from being added to the collection,
+// in which case records must be added one at a time so that each value is checked.
+func (sm *SortedMap) rejectsVals() bool {
+	return sm.rejectNaN || sm.uniqueVals
+}
+
+// rejects reports whether the options given to New prevent val from being added to the collection using key.
+func (sm *SortedMap) rejects(key, val interface{}) bool {
+	if sm.rejectNaN && isNaN(val) {
+		return true
+	}
+	if sm.uniqueVals {
+		for _, k := range sm.equalRange(val) {
+			if k != key {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package sortedmap
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func TestRejectNaN(t *testing.T) {
+	sm := New(0, asc.Float64Total, RejectNaN())
+
+	if sm.Insert("nan", math.NaN()) {
+		t.Fatal("TestRejectNaN failed: a NaN value was inserted.")
+	}
+	if !sm.Insert("a", 1.0) || !sm.Insert("b", 2.0) {
+		t.Fatal("TestRejectNaN failed: a number was not inserted.")
+	}
+
+	sm.Replace("a", math.NaN())
+	if val, ok := sm.Get("a"); !ok || val != 1.0 {
+		t.Fatalf("TestRejectNaN failed: Replace changed the value to %v.", val)
+	}
+
+	if err := sm.BatchInsertMap(map[string]interface{}{"c": math.NaN()}); err == nil {
+		t.Fatal("TestRejectNaN failed: BatchInsertMap did not return an error.")
+	}
+	if err := sm.Apply([]Change{{Type: Changed, Key: "a", NewVal: math.NaN()}}); err == nil {
+		t.Fatal("TestRejectNaN failed: Apply did not return an error.")
+	}
+
+	if sm.Clone().Insert("nan", math.NaN()) {
+		t.Fatal("TestRejectNaN failed: the clone did not keep the option.")
+	}
+	if sm.Len() != 2 {
+		t.Fatalf("TestRejectNaN failed: expected 2 records, got %v.", sm.Len())
+	}
+
+	if New(0, asc.Float32Total, RejectNaN()).Insert("nan", float32(math.NaN())) {
+		t.Fatal("TestRejectNaN failed: a float32 NaN value was inserted.")
+	}
+	if !New(0, asc.Float64Total).Insert("nan", math.NaN()) {
+		t.Fatal("TestRejectNaN failed: a NaN value was rejected without the option.")
+	}
+}
+
+func TestRejectNaNSetOps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+
+	sm := New(0, asc.Float64Total, RejectNaN())
+	sm.SetCodecs(StringCodec, Float64Codec)
+	wal, err := sm.OpenWAL(path, WALParams{SyncPolicy: SyncNever})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm.Insert("a", 1.0)
+	sm.Insert("b", 2.0)
+
+	other := New(0, asc.Float64Total)
+	other.Insert("a", 3.0)
+	other.Insert("c", math.NaN())
+	other.Insert("d", 4.0)
+
+	toNaN := func(_, _, _ interface{}) interface{} {
+		return math.NaN()
+	}
+	for _, result := range []*SortedMap{Union(sm, other, toNaN), Intersect(sm, other, toNaN)} {
+		for _, key := range result.Keys() {
+			if val, _ := result.Get(key); isNaN(val) {
+				t.Fatalf("TestRejectNaNSetOps failed: key %v has a NaN value.", key)
+			}
+		}
+	}
+
+	sm.MergeFrom(other, toNaN)
+	if err := verifyRecs(sm, []Record{{Key: "a", Val: 1.0}, {Key: "b", Val: 2.0}, {Key: "d", Val: 4.0}}); err != nil {
+		t.Fatalf("TestRejectNaNSetOps failed: %v", err)
+	}
+	sm.IntersectFrom(other, toNaN)
+	if err := verifyRecs(sm, []Record{{Key: "a", Val: 1.0}, {Key: "d", Val: 4.0}}); err != nil {
+		t.Fatalf("TestRejectNaNSetOps failed: %v", err)
+	}
+
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := New(0, asc.Float64Total, RejectNaN())
+	replayed.SetCodecs(StringCodec, Float64Codec)
+	wal, err = replayed.OpenWAL(path, WALParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wal.Close()
+
+	if err := verifyEqualMaps(sm, replayed); err != nil {
+		t.Fatalf("TestRejectNaNSetOps failed: %v", err)
+	}
+}
+
+func TestRejectNaNDecode(t *testing.T) {
+	src := New(0, asc.Float64Total)
+	src.Insert("a", 1.0)
+	src.Insert("b", math.NaN())
+	src.SetCodecs(StringCodec, Float64Codec)
+
+	gobData, err := src.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := src.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, decode := range map[string]func(sm *SortedMap) error{
+		"GobDecode": func(sm *SortedMap) error {
+			return sm.GobDecode(gobData)
+		},
+		"UnmarshalJSON": func(sm *SortedMap) error {
+			sm.SetJSONDecoders(nil, func(data []byte) (interface{}, error) {
+				if string(data) == "null" {
+					return math.NaN(), nil
+				}
+				return decodeJSONInterface(data)
+			})
+			return sm.UnmarshalJSON([]byte(`{"a":1,"b":null}`))
+		},
+		"ReadFrom": func(sm *SortedMap) error {
+			_, err := sm.ReadFrom(bytes.NewReader(buf.Bytes()))
+			return err
+		},
+	} {
+		sm := New(0, asc.Float64Total, RejectNaN())
+		sm.SetCodecs(StringCodec, Float64Codec)
+		sm.Insert("z", 9.0)
+
+		if err := decode(sm); err == nil {
+			t.Fatalf("TestRejectNaNDecode failed: %v accepted a NaN value.", name)
+		}
+		if sm.Len() != 1 || sm.idx["z"] != 9.0 {
+			t.Fatalf("TestRejectNaNDecode failed: %v changed the collection: %v", name, sm.Map())
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package sortedmap
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// parallelChunksPerWorker is the number of chunks created for each worker,
+// so that workers that finish early can take on more of the range.
+const parallelChunksPerWorker = 4
+
+// ParallelFunc defines the type of function that is passed into ParallelIterFunc.
+// The function is passed a contiguous chunk of sorted records and returns a result for the chunk.
+type ParallelFunc func(ctx context.Context, recs []Record) (interface{}, error)
+
+// ParallelIterFunc splits the records with values within the given bounds, as selected by BoundedKeys, into contiguous chunks,
+// which are passed to f by a pool of worker goroutines, and returns the result of each chunk in sorted order.
+// If workers is less than 1, runtime.GOMAXPROCS(0) workers are used.
+// If f returns an error or ctx is canceled, the context passed to f is canceled, no more chunks are started,
+// and the first error is returned. Either bound can be nil to leave the range unbounded.
+// The collection must not be modified until ParallelIterFunc returns.
+func (sm *SortedMap) ParallelIterFunc(ctx context.Context, lowerBound, upperBound interface{}, workers int, f ParallelFunc) ([]interface{}, error) {
+	iterBounds := sm.boundsIdxSearch(lowerBound, upperBound)
+	if iterBounds == nil {
+		return nil, errors.New(noValuesErr)
+	}
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	n := iterBounds[1] - iterBounds[0] + 1
+	chunks := workers * parallelChunksPerWorker
+	if chunks > n {
+		chunks = n
+	}
+	if workers > chunks {
+		workers = chunks
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results  = make([]interface{}, chunks)
+		chunkCh  = make(chan int)
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for c := range chunkCh {
+				if ctx.Err() != nil {
+					continue
+				}
+				lo, hi := iterBounds[0]+c*n/chunks, iterBounds[0]+(c+1)*n/chunks
+
+				recs := make([]Record, 0, hi-lo)
+				for i := lo; i < hi; i++ {
+					recs = append(recs, sm.recordFromIdx(i))
+				}
+
+				result, err := f(ctx, recs)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[c] = result
+			}
+		}()
+	}
+
+sendChunks:
+	for c := 0; c < chunks; c++ {
+		select {
+		case chunkCh <- c:
+		case <-ctx.Done():
+			break sendChunks
+		}
+	}
+	close(chunkCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
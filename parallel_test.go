@@ -0,0 +1,88 @@
+package sortedmap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func collectKeys(_ context.Context, recs []Record) (interface{}, error) {
+	keys := make([]interface{}, len(recs))
+	for i, rec := range recs {
+		keys[i] = rec.Key
+	}
+	return keys, nil
+}
+
+func TestParallelIterFunc(t *testing.T) {
+	sm, records, err := newSortedMapFromRandRecords(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, workers := range []int{0, 1, 3, 2000} {
+		results, err := sm.ParallelIterFunc(context.Background(), nil, nil, workers, collectKeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var keys []interface{}
+		for _, result := range results {
+			keys = append(keys, result.([]interface{})...)
+		}
+		sortedKeys := sm.Keys()
+		if len(keys) != len(sortedKeys) {
+			t.Fatalf("TestParallelIterFunc failed: expected %v keys, got %v.", len(sortedKeys), len(keys))
+		}
+		for i := range keys {
+			if keys[i] != sortedKeys[i] {
+				t.Fatalf("TestParallelIterFunc failed: results were not in sorted order using %v workers.", workers)
+			}
+		}
+	}
+
+	lowerBound, upperBound := records[0].Val, records[1].Val
+	if upperBound.(time.Time).Before(lowerBound.(time.Time)) {
+		lowerBound, upperBound = upperBound, lowerBound
+	}
+	boundedKeys, boundedErr := sm.BoundedKeys(lowerBound, upperBound)
+	results, err := sm.ParallelIterFunc(context.Background(), lowerBound, upperBound, 4, collectKeys)
+	if (err == nil) != (boundedErr == nil) {
+		t.Fatalf("TestParallelIterFunc failed: unexpected error for bounds: %v", err)
+	}
+	count := 0
+	for _, result := range results {
+		count += len(result.([]interface{}))
+	}
+	if count != len(boundedKeys) {
+		t.Fatalf("TestParallelIterFunc failed: expected %v bounded keys, got %v.", len(boundedKeys), count)
+	}
+}
+
+func TestParallelIterFuncErrors(t *testing.T) {
+	sm, _, err := newSortedMapFromRandRecords(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunkErr := errors.New("chunk failed")
+	calls := 0
+	_, err = sm.ParallelIterFunc(context.Background(), nil, nil, 1, func(ctx context.Context, recs []Record) (interface{}, error) {
+		calls++
+		return nil, chunkErr
+	})
+	if err != chunkErr || calls != 1 {
+		t.Fatalf("TestParallelIterFuncErrors failed: expected the first error after 1 call, got %v after %v calls.", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := sm.ParallelIterFunc(ctx, nil, nil, 4, collectKeys); err != context.Canceled {
+		t.Fatalf("TestParallelIterFuncErrors failed: expected context.Canceled, got %v.", err)
+	}
+
+	if _, err := New(0, nil).ParallelIterFunc(context.Background(), nil, nil, 4, collectKeys); err == nil {
+		t.Fatal("TestParallelIterFuncErrors failed: no error was returned for an empty collection.")
+	}
+}
@@ -0,0 +1,87 @@
+package sortedmap
+
+import "math"
+
+// InterpolateFunc defines the type of function used to compute a value between two neighbouring values,
+// where frac is the fractional distance from lower to upper, in the range [0, 1).
+type InterpolateFunc func(lower, upper interface{}, frac float64) interface{}
+
+// InterpolateFloat64 linearly interpolates between two float64 values.
+func InterpolateFloat64(lower, upper interface{}, frac float64) interface{} {
+	l := lower.(float64)
+	return l + (upper.(float64)-l)*frac
+}
+
+// Interpolate returns an Option that makes quantile queries interpolate between the two values nearest to a quantile's position,
+// instead of selecting a single record by nearest rank.
+func Interpolate(fn InterpolateFunc) Option {
+	return func(sm *SortedMap) {
+		sm.interpolateFn = fn
+	}
+}
+
+// quantile returns the q-quantile of the records at positions lo to hi, inclusive.
+func (sm *SortedMap) quantile(lo, hi int, q float64) (Record, bool) {
+	if lo > hi || !(q >= 0 && q <= 1) {
+		return Record{}, false
+	}
+	n := hi - lo + 1
+
+	if sm.interpolateFn == nil {
+		// Nearest rank: the least value with at least q*n values equal to or less than it.
+		i := int(math.Ceil(q*float64(n))) - 1
+		if i < 0 {
+			i = 0
+		}
+		key := sm.sorted[lo+i]
+		return Record{Key: key, Val: sm.idx[key]}, true
+	}
+
+	pos := q * float64(n-1)
+	i := int(pos)
+	key := sm.sorted[lo+i]
+	frac := pos - float64(i)
+	if frac == 0 {
+		return Record{Key: key, Val: sm.idx[key]}, true
+	}
+	return Record{Val: sm.interpolateFn(sm.idx[key], sm.idx[sm.sorted[lo+i+1]], frac)}, true
+}
+
+// Quantile returns the record at the q-quantile of the collection, where q is in the range [0, 1], in O(1) time.
+// By default, the record is selected using the nearest-rank method.
+// If the Interpolate option was given, the value is interpolated between the two nearest values,
+// and the returned record's key is nil unless the quantile falls exactly on a record.
+// The second result is false if the collection is empty or q is out of range.
+func (sm *SortedMap) Quantile(q float64) (Record, bool) {
+	return sm.quantile(0, len(sm.sorted)-1, q)
+}
+
+// Quantiles returns the record at each of the given quantiles, in the same way as Quantile.
+// The second result is false if the collection is empty or any quantile is out of range.
+func (sm *SortedMap) Quantiles(qs []float64) ([]Record, bool) {
+	recs := make([]Record, len(qs))
+	for i, q := range qs {
+		rec, ok := sm.Quantile(q)
+		if !ok {
+			return nil, false
+		}
+		recs[i] = rec
+	}
+	return recs, true
+}
+
+// Median returns the record at the 0.5-quantile of the collection, in the same way as Quantile.
+func (sm *SortedMap) Median() (Record, bool) {
+	return sm.Quantile(0.5)
+}
+
+// QuantileRange returns the record at the q-quantile of the values within the given bounds,
+// in the same way as Quantile, in O(log n) time. The values are selected in the same way as BoundedKeys.
+// The second result is false if no values are within the bounds or q is out of range.
+func (sm *SortedMap) QuantileRange(lowerBound, upperBound interface{}, q float64) (Record, bool) {
+	iterBounds := sm.boundsIdxSearch(lowerBound, upperBound)
+	if iterBounds == nil {
+		return Record{}, false
+	}
+	return sm.quantile(iterBounds[0], iterBounds[1], q)
+}
@@ -0,0 +1,69 @@
+package sortedmap
+
+import (
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func newQuantileTestMap(n int, opts ...Option) *SortedMap {
+	sm := New(n, asc.Float64, opts...)
+	for i := n; i > 0; i-- {
+		sm.Insert(i, float64(i))
+	}
+	return sm
+}
+
+func TestQuantile(t *testing.T) {
+	sm := newQuantileTestMap(100)
+
+	for q, expected := range map[float64]float64{0: 1, 0.5: 50, 0.95: 95, 0.99: 99, 1: 100, 0.001: 1} {
+		rec, ok := sm.Quantile(q)
+		if !ok || rec.Val != expected || rec.Key != int(expected) {
+			t.Fatalf("TestQuantile failed: quantile %v. Expected: %v, Had: %+v.", q, expected, rec)
+		}
+	}
+	for _, q := range []float64{-0.1, 1.1} {
+		if _, ok := sm.Quantile(q); ok {
+			t.Fatalf("TestQuantile failed: quantile %v was accepted.", q)
+		}
+	}
+	if _, ok := New(0, asc.Float64).Median(); ok {
+		t.Fatal("TestQuantile failed: an empty collection returned a median.")
+	}
+
+	recs, ok := sm.Quantiles([]float64{0.5, 0.95, 0.99})
+	if !ok || len(recs) != 3 || recs[0].Val != 50.0 || recs[1].Val != 95.0 || recs[2].Val != 99.0 {
+		t.Fatalf("TestQuantile failed: unexpected quantiles: %+v", recs)
+	}
+	if _, ok := sm.Quantiles([]float64{0.5, 2}); ok {
+		t.Fatal("TestQuantile failed: an out of range quantile was accepted.")
+	}
+}
+
+func TestQuantileInterpolate(t *testing.T) {
+	sm := newQuantileTestMap(4, Interpolate(InterpolateFloat64))
+
+	rec, ok := sm.Median()
+	if !ok || rec.Val != 2.5 || rec.Key != nil {
+		t.Fatalf("TestQuantileInterpolate failed: unexpected median: %+v", rec)
+	}
+	if rec, ok = sm.Quantile(1); !ok || rec.Val != 4.0 || rec.Key != 4 {
+		t.Fatalf("TestQuantileInterpolate failed: unexpected maximum: %+v", rec)
+	}
+	if rec, ok = sm.Clone().Quantile(0.25); !ok || rec.Val != 1.75 {
+		t.Fatalf("TestQuantileInterpolate failed: unexpected quantile from clone: %+v", rec)
+	}
+}
+
+func TestQuantileRange(t *testing.T) {
+	sm := newQuantileTestMap(100)
+
+	rec, ok := sm.QuantileRange(10.0, 30.0, 0.5)
+	if !ok || rec.Val != 20.0 {
+		t.Fatalf("TestQuantileRange failed: unexpected median: %+v", rec)
+	}
+	if _, ok := sm.QuantileRange(200.0, nil, 0.5); ok {
+		t.Fatal("TestQuantileRange failed: an empty range returned a quantile.")
+	}
+}
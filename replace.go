@@ -3,12 +3,19 @@ package sortedmap
 import "errors"
 
 func (sm *SortedMap) replace(key, val interface{}) {
-	sm.delete(key)
-	sm.insert(key, val)
+	if sm.rejects(key, val) {
+		return
+	}
+	sm.deleteRecord(key)
+	sm.insertRecord(key, val)
+	sm.updateAggregate()
+	sm.wal.logReplace(key, val)
+	sm.checkOrder("Replace")
 }
 
 // Replace uses the provided 'less than' function to insert sort.
 // Even if the key already exists, the value will be inserted.
+// If the value is rejected by an Option, the collection is left unchanged.
 // Use Insert for the alternative functionality.
 func (sm *SortedMap) Replace(key, val interface{}) {
 	sm.replace(key, val)
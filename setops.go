@@ -0,0 +1,247 @@
+package sortedmap
+
+import "sort"
+
+// MergeFunc defines the type of function used to choose the value of a key that exists in both collections.
+// The function is passed the key and its values from the first and second collections.
+type MergeFunc func(key, aVal, bVal interface{}) interface{}
+
+func keepFirstVal(_, aVal, _ interface{}) interface{} {
+	return aVal
+}
+
+func setMergeFunc(fn MergeFunc) MergeFunc {
+	if fn == nil {
+		return keepFirstVal
+	}
+	return fn
+}
+
+// mergeRecords merges two slices of records sorted by lessFn in linear time.
+// Records from a are placed before records from b that have equal values.
+func mergeRecords(a, b []Record, lessFn ComparisonFunc) []Record {
+	merged := make([]Record, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if lessFn(b[j].Val, a[i].Val) {
+			merged = append(merged, b[j])
+			j++
+		} else {
+			merged = append(merged, a[i])
+			i++
+		}
+	}
+	merged = append(merged, a[i:]...)
+
+	return append(merged, b[j:]...)
+}
+
+func sortRecords(recs []Record, lessFn ComparisonFunc) {
+	if !sort.SliceIsSorted(recs, func(i, j int) bool {
+		return lessFn(recs[i].Val, recs[j].Val)
+	}) {
+		sort.SliceStable(recs, func(i, j int) bool {
+			return lessFn(recs[i].Val, recs[j].Val)
+		})
+	}
+}
+
+// newFromSortedRecords returns a new collection containing recs, which are sorted by the comparison function of like.
+// The new collection has the same comparison function, options, codecs and JSON decoders as like.
+func newFromSortedRecords(like *SortedMap, recs []Record) *SortedMap {
+	sm := like.emptyCopy()
+	sm.setSortedRecords(recs)
+	return sm
+}
+
+// partition splits the records of sm into records with keys that are missing from other and records with keys that are shared,
+// keeping their sorted order.
+func (sm *SortedMap) partition(other *SortedMap) ([]Record, []Record) {
+	missing := make([]Record, 0)
+	shared := make([]Record, 0)
+
+	for i, key := range sm.sorted {
+		if _, ok := other.idx[key]; ok {
+			shared = append(shared, sm.recordFromIdx(i))
+		} else {
+			missing = append(missing, sm.recordFromIdx(i))
+		}
+	}
+	return missing, shared
+}
+
+func union(a, b *SortedMap, fn MergeFunc) []Record {
+	fn = setMergeFunc(fn)
+
+	onlyA, shared := a.partition(b)
+	onlyB, _ := b.partition(a)
+	sortRecords(onlyB, a.lessFn)
+
+	for i := range shared {
+		shared[i].Val = fn(shared[i].Key, shared[i].Val, b.idx[shared[i].Key])
+	}
+	sortRecords(shared, a.lessFn)
+
+	return mergeRecords(mergeRecords(onlyA, onlyB, a.lessFn), shared, a.lessFn)
+}
+
+func intersect(a, b *SortedMap, fn MergeFunc) []Record {
+	fn = setMergeFunc(fn)
+
+	_, shared := a.partition(b)
+	for i := range shared {
+		shared[i].Val = fn(shared[i].Key, shared[i].Val, b.idx[shared[i].Key])
+	}
+	sortRecords(shared, a.lessFn)
+
+	return shared
+}
+
+func difference(a, b *SortedMap) []Record {
+	onlyA, _ := a.partition(b)
+	return onlyA
+}
+
+// Union returns a new SortedMap containing the records of both collections, using the comparison function and options of a.
+// The value of a key that exists in both collections is chosen by fn, or is taken from a if fn is nil.
+// Records are merged in linear time when both collections are sorted by the same comparison function,
+// and the records of b are sorted first otherwise.
+// If the options of a reject values, the records of b are merged one at a time, as by MergeFrom.
+func Union(a, b *SortedMap, fn MergeFunc) *SortedMap {
+	if a.rejectsVals() {
+		sm := newFromSortedRecords(a, a.records())
+		sm.mergeEach(b, fn)
+		return sm
+	}
+	return newFromSortedRecords(a, union(a, b, fn))
+}
+
+// Intersect returns a new SortedMap containing the records with keys that exist in both collections, using the comparison function and options of a.
+// The value of each key is chosen by fn, or is taken from a if fn is nil.
+// If the options of a reject values, the values chosen by fn are replaced one at a time, as by IntersectFrom.
+func Intersect(a, b *SortedMap, fn MergeFunc) *SortedMap {
+	if a.rejectsVals() {
+		sm := newFromSortedRecords(a, a.records())
+		sm.intersectEach(b, fn)
+		return sm
+	}
+	return newFromSortedRecords(a, intersect(a, b, fn))
+}
+
+// Difference returns a new SortedMap containing the records of a with keys that do not exist in b, using the comparison function and options of a.
+func Difference(a, b *SortedMap) *SortedMap {
+	return newFromSortedRecords(a, difference(a, b))
+}
+
+func (sm *SortedMap) setSortedRecords(recs []Record) {
+	idx := make(map[interface{}]interface{}, len(recs))
+	sorted := make([]interface{}, 0, len(recs))
+	for _, rec := range recs {
+		idx[rec.Key] = rec.Val
+		sorted = append(sorted, rec.Key)
+	}
+	sm.setRecords(idx, sorted)
+}
+
+// mergeEach adds the records of other to the collection one at a time, using insert and replace,
+// so that values rejected by the collection's options are skipped.
+func (sm *SortedMap) mergeEach(other *SortedMap, fn MergeFunc) {
+	for _, rec := range other.records() {
+		if val, ok := sm.idx[rec.Key]; !ok {
+			sm.insert(rec.Key, rec.Val)
+		} else if fn != nil {
+			sm.replace(rec.Key, fn(rec.Key, val, rec.Val))
+		}
+	}
+}
+
+// intersectEach removes the records with keys that do not exist in other, and then replaces the values of the remaining keys
+// one at a time, so that values rejected by the collection's options are skipped.
+func (sm *SortedMap) intersectEach(other *SortedMap, fn MergeFunc) {
+	recs := sm.records()
+	for _, rec := range recs {
+		if _, ok := other.idx[rec.Key]; !ok {
+			sm.delete(rec.Key)
+		}
+	}
+	if fn == nil {
+		return
+	}
+	for _, rec := range recs {
+		if otherVal, ok := other.idx[rec.Key]; ok {
+			sm.replace(rec.Key, fn(rec.Key, rec.Val, otherVal))
+		}
+	}
+}
+
+// MergeFrom adds the records of other to the collection, in linear time.
+// The value of a key that exists in both collections is chosen by fn, or is kept if fn is nil.
+// If the collection's options reject values, records are merged one at a time, as by Insert and Replace,
+// so that rejected values are skipped and a key that exists in both collections keeps its value.
+func (sm *SortedMap) MergeFrom(other *SortedMap, fn MergeFunc) {
+	if sm.rejectsVals() {
+		sm.mergeEach(other, fn)
+		return
+	}
+
+	recs := union(sm, other, fn)
+
+	if sm.wal != nil {
+		for _, key := range other.sorted {
+			if _, ok := sm.idx[key]; !ok {
+				sm.wal.logInsert(key, other.idx[key])
+			}
+		}
+		if fn != nil {
+			for _, rec := range recs {
+				if _, ok := other.idx[rec.Key]; ok && sm.Has(rec.Key) {
+					sm.wal.logReplace(rec.Key, rec.Val)
+				}
+			}
+		}
+	}
+
+	sm.setSortedRecords(recs)
+}
+
+// IntersectFrom removes records with keys that do not exist in other from the collection, in linear time.
+// The value of each remaining key is chosen by fn, or is kept if fn is nil.
+// If the collection's options reject values, the values chosen by fn are replaced one at a time, as by Replace,
+// so that a key with a rejected value keeps its value.
+func (sm *SortedMap) IntersectFrom(other *SortedMap, fn MergeFunc) {
+	if sm.rejectsVals() {
+		sm.intersectEach(other, fn)
+		return
+	}
+
+	recs := intersect(sm, other, fn)
+
+	if sm.wal != nil {
+		for _, key := range sm.sorted {
+			if _, ok := other.idx[key]; !ok {
+				sm.wal.logDelete(key)
+			}
+		}
+		if fn != nil {
+			for _, rec := range recs {
+				sm.wal.logReplace(rec.Key, rec.Val)
+			}
+		}
+	}
+
+	sm.setSortedRecords(recs)
+}
+
+// DifferenceFrom removes records with keys that exist in other from the collection, in linear time.
+func (sm *SortedMap) DifferenceFrom(other *SortedMap) {
+	if sm.wal != nil {
+		for _, key := range sm.sorted {
+			if _, ok := other.idx[key]; ok {
+				sm.wal.logDelete(key)
+			}
+		}
+	}
+
+	sm.setSortedRecords(difference(sm, other))
+}
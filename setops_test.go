@@ -0,0 +1,195 @@
+package sortedmap
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func newSetOpsTestMaps() (*SortedMap, *SortedMap) {
+	a := New(4, asc.Int)
+	a.Insert("a", 1)
+	a.Insert("b", 3)
+	a.Insert("c", 5)
+	a.Insert("d", 7)
+
+	b := New(4, asc.Int)
+	b.Insert("c", 2)
+	b.Insert("e", 4)
+	b.Insert("a", 6)
+	b.Insert("f", 8)
+
+	return a, b
+}
+
+func sumVals(_, aVal, bVal interface{}) interface{} {
+	return aVal.(int) + bVal.(int)
+}
+
+func verifyRecs(sm *SortedMap, expected []Record) error {
+	if sm.Len() != len(expected) {
+		return fmt.Errorf("length mismatch. Expected: %v, Had: %v.", len(expected), sm.Len())
+	}
+	for i, rec := range expected {
+		if had := sm.recordFromIdx(i); had != rec {
+			return fmt.Errorf("record mismatch at index %v. Expected: %+v, Had: %+v.", i, rec, had)
+		}
+	}
+	return nil
+}
+
+func TestUnion(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+
+	if err := verifyRecs(Union(a, b, nil), []Record{
+		{"a", 1}, {"b", 3}, {"e", 4}, {"c", 5}, {"d", 7}, {"f", 8},
+	}); err != nil {
+		t.Fatalf("TestUnion failed: %v", err)
+	}
+	if err := verifyRecs(Union(a, b, sumVals), []Record{
+		{"b", 3}, {"e", 4}, {"d", 7}, {"a", 7}, {"c", 7}, {"f", 8},
+	}); err != nil {
+		t.Fatalf("TestUnion failed: %v", err)
+	}
+	if err := verifyRecs(Union(New(0, asc.Int), b, nil), []Record{
+		{"c", 2}, {"e", 4}, {"a", 6}, {"f", 8},
+	}); err != nil {
+		t.Fatalf("TestUnion failed: %v", err)
+	}
+}
+
+func TestUnionWithDifferentOrder(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+	b = b.CloneWith(desc.Int)
+
+	expected := []Record{
+		{Key: "a", Val: 1},
+		{Key: "b", Val: 3},
+		{Key: "e", Val: 4},
+		{Key: "c", Val: 5},
+		{Key: "d", Val: 7},
+		{Key: "f", Val: 8},
+	}
+	if err := verifyRecs(Union(a, b, nil), expected); err != nil {
+		t.Fatalf("TestUnionWithDifferentOrder failed: %v", err)
+	}
+
+	a.MergeFrom(b, nil)
+	if err := verifyRecs(a, expected); err != nil {
+		t.Fatalf("TestUnionWithDifferentOrder failed: %v", err)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+
+	if err := verifyRecs(Intersect(a, b, nil), []Record{
+		{"a", 1}, {"c", 5},
+	}); err != nil {
+		t.Fatalf("TestIntersect failed: %v", err)
+	}
+	if err := verifyRecs(Intersect(b, a, sumVals), []Record{
+		{"c", 7}, {"a", 7},
+	}); err != nil {
+		t.Fatalf("TestIntersect failed: %v", err)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+
+	if err := verifyRecs(Difference(a, b), []Record{
+		{"b", 3}, {"d", 7},
+	}); err != nil {
+		t.Fatalf("TestDifference failed: %v", err)
+	}
+	if err := verifyRecs(Difference(b, New(0, asc.Int)), []Record{
+		{"c", 2}, {"e", 4}, {"a", 6}, {"f", 8},
+	}); err != nil {
+		t.Fatalf("TestDifference failed: %v", err)
+	}
+}
+
+func TestSetOpsLeaveInputsUnchanged(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+	Union(a, b, sumVals)
+	Intersect(a, b, sumVals)
+	Difference(a, b)
+
+	expectedA, expectedB := newSetOpsTestMaps()
+	if err := verifyEqualMaps(expectedA, a); err != nil {
+		t.Fatalf("TestSetOpsLeaveInputsUnchanged failed: %v", err)
+	}
+	if err := verifyEqualMaps(expectedB, b); err != nil {
+		t.Fatalf("TestSetOpsLeaveInputsUnchanged failed: %v", err)
+	}
+}
+
+func TestMergeFrom(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+	a.MergeFrom(b, sumVals)
+
+	expectedA, expectedB := newSetOpsTestMaps()
+	if err := verifyEqualMaps(Union(expectedA, expectedB, sumVals), a); err != nil {
+		t.Fatalf("TestMergeFrom failed: %v", err)
+	}
+}
+
+func TestIntersectFrom(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+	a.IntersectFrom(b, sumVals)
+
+	expectedA, expectedB := newSetOpsTestMaps()
+	if err := verifyEqualMaps(Intersect(expectedA, expectedB, sumVals), a); err != nil {
+		t.Fatalf("TestIntersectFrom failed: %v", err)
+	}
+	if !a.Insert("g", 0) {
+		t.Fatalf("TestIntersectFrom failed: %v", keyExistsErr)
+	}
+}
+
+func TestDifferenceFrom(t *testing.T) {
+	a, b := newSetOpsTestMaps()
+	a.DifferenceFrom(b)
+
+	if err := verifyEqualMaps(Difference(newSetOpsTestMaps()), a); err != nil {
+		t.Fatalf("TestDifferenceFrom failed: %v", err)
+	}
+}
+
+func TestInPlaceSetOpsWithWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+
+	a, b := newSetOpsTestMaps()
+	a.SetCodecs(StringCodec, IntCodec)
+	wal, err := a.OpenWAL(path, WALParams{SyncPolicy: SyncNever})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.MergeFrom(b, sumVals)
+	b.Replace("g", 9)
+	a.IntersectFrom(b, sumVals)
+	a.DifferenceFrom(Intersect(b, New(0, asc.Int), nil))
+	b.Delete("e")
+	a.DifferenceFrom(b)
+
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := New(0, asc.Int)
+	replayed.SetCodecs(StringCodec, IntCodec)
+	wal, err = replayed.OpenWAL(path, WALParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wal.Close()
+
+	if err := verifyEqualMaps(a, replayed); err != nil {
+		t.Fatalf("TestInPlaceSetOpsWithWAL failed: %v", err)
+	}
+}
@@ -0,0 +1,284 @@
+package sortedmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// Snapshot format:
+//
+//	magic            4 bytes, "SMAP"
+//	version          uvarint
+//	record count     uvarint
+//	comparator name  uvarint length + bytes, empty unless set using ComparatorName
+//	records          uvarint length + key bytes, uvarint length + value bytes, in sorted order
+//	checksum         4 bytes, big-endian CRC-32 (Castagnoli) of all preceding bytes
+const (
+	snapshotMagic   = "SMAP"
+	snapshotVersion = 1
+)
+
+const (
+	invalidMagicErr       = "Invalid snapshot: the magic number did not match."
+	unsupportedVerErr     = "Unsupported snapshot format version: %v"
+	checksumMismatchErr   = "Snapshot checksum mismatch. Expected: %#08x, Had: %#08x."
+	comparatorMismatchErr = "Snapshot comparison function mismatch. Expected: %v, Had: %v."
+	truncatedErr          = "Truncated snapshot"
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ComparatorName returns an Option that names the comparison function given to New or NewWithCompare.
+// The name is written to snapshots, and ReadFrom returns an error if a snapshot was written with a different name.
+// Snapshots written without a name, or read into a collection without a name, are only checked for their order.
+// SetComparisonFunc and SetCompareFunc clear the name, because it no longer describes the comparison function.
+func ComparatorName(name string) Option {
+	return func(sm *SortedMap) {
+		sm.cmpName = name
+	}
+}
+
+// binWriter writes length-prefixed fields while tracking the byte count and a running checksum.
+// The first error is kept and all later writes are skipped.
+type binWriter struct {
+	w   io.Writer
+	crc hash.Hash32
+	n   int64
+	err error
+}
+
+func newBinWriter(w io.Writer) *binWriter {
+	return &binWriter{
+		w:   w,
+		crc: crc32.New(crcTable),
+	}
+}
+
+func (bw *binWriter) write(p []byte) {
+	if bw.err != nil {
+		return
+	}
+	n, err := bw.w.Write(p)
+	bw.crc.Write(p[:n])
+	bw.n += int64(n)
+	bw.err = err
+}
+
+func (bw *binWriter) writeUvarint(x uint64) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	bw.write(buf[:binary.PutUvarint(buf, x)])
+}
+
+func (bw *binWriter) writeBytes(p []byte) {
+	bw.writeUvarint(uint64(len(p)))
+	bw.write(p)
+}
+
+func (bw *binWriter) writeEncoded(encFn EncodeFunc, v interface{}) {
+	if bw.err != nil {
+		return
+	}
+	data, err := encFn(v)
+	if err != nil {
+		bw.err = err
+		return
+	}
+	bw.writeBytes(data)
+}
+
+func (bw *binWriter) writeChecksum() {
+	buf := make([]byte, crc32.Size)
+	binary.BigEndian.PutUint32(buf, bw.crc.Sum32())
+	bw.write(buf)
+}
+
+// binReader reads fields written by binWriter while tracking the byte count and a running checksum.
+type binReader struct {
+	r   io.Reader
+	crc hash.Hash32
+	n   int64
+	buf [1]byte
+}
+
+func newBinReader(r io.Reader) *binReader {
+	return &binReader{
+		r:   r,
+		crc: crc32.New(crcTable),
+	}
+}
+
+func (br *binReader) Read(p []byte) (int, error) {
+	n, err := br.r.Read(p)
+	br.crc.Write(p[:n])
+	br.n += int64(n)
+	return n, err
+}
+
+func (br *binReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(br, br.buf[:]); err != nil {
+		return 0, err
+	}
+	return br.buf[0], nil
+}
+
+func (br *binReader) readFull(p []byte) error {
+	_, err := io.ReadFull(br, p)
+	return err
+}
+
+func (br *binReader) readUvarint() (uint64, error) {
+	return binary.ReadUvarint(br)
+}
+
+// readBytes reads a length-prefixed field.
+// The buffer grows as data arrives, so a corrupted length cannot cause a large allocation on its own.
+func (br *binReader) readBytes() ([]byte, error) {
+	l, err := br.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if _, err := io.CopyN(buf, br, int64(l)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (br *binReader) readDecoded(decFn DecodeFunc) (interface{}, error) {
+	data, err := br.readBytes()
+	if err != nil {
+		return nil, err
+	}
+	return decFn(data)
+}
+
+// verifyChecksum reads a trailing checksum and compares it with the checksum of all bytes read before it.
+func (br *binReader) verifyChecksum() error {
+	expected := br.crc.Sum32()
+
+	buf := make([]byte, crc32.Size)
+	if err := br.readFull(buf); err != nil {
+		return err
+	}
+	if had := binary.BigEndian.Uint32(buf); had != expected {
+		return fmt.Errorf(checksumMismatchErr, expected, had)
+	}
+	return nil
+}
+
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%v: %w", truncatedErr, io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+// SetCodecs sets the codecs that WriteTo and ReadFrom use to convert keys and values to and from bytes.
+// GobCodec is used for any codec that is left unset.
+func (sm *SortedMap) SetCodecs(keyCodec, valCodec Codec) {
+	sm.keyCodec = keyCodec
+	sm.valCodec = valCodec
+}
+
+// WriteTo implements the io.WriterTo interface by writing a binary snapshot of the collection to w.
+// The snapshot contains a header with the format version, the record count and the name given to ComparatorName,
+// followed by the records in sorted order and a trailing checksum.
+func (sm *SortedMap) WriteTo(w io.Writer) (int64, error) {
+	keyCodec, valCodec := setCodec(sm.keyCodec), setCodec(sm.valCodec)
+
+	buf := bufio.NewWriter(w)
+	bw := newBinWriter(buf)
+
+	bw.write([]byte(snapshotMagic))
+	bw.writeUvarint(snapshotVersion)
+	bw.writeUvarint(uint64(len(sm.sorted)))
+	bw.writeBytes([]byte(sm.cmpName))
+
+	for _, key := range sm.sorted {
+		bw.writeEncoded(keyCodec.Encode, key)
+		bw.writeEncoded(valCodec.Encode, sm.idx[key])
+	}
+	bw.writeChecksum()
+
+	if bw.err != nil {
+		return bw.n, bw.err
+	}
+	return bw.n, buf.Flush()
+}
+
+func (sm *SortedMap) readSnapshot(br *binReader) ([]Record, error) {
+	magic := make([]byte, len(snapshotMagic))
+	if err := br.readFull(magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != snapshotMagic {
+		return nil, errors.New(invalidMagicErr)
+	}
+
+	version, err := br.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if version != snapshotVersion {
+		return nil, fmt.Errorf(unsupportedVerErr, version)
+	}
+
+	count, err := br.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	cmpName, err := br.readBytes()
+	if err != nil {
+		return nil, err
+	}
+	if sm.cmpName != "" && len(cmpName) > 0 && string(cmpName) != sm.cmpName {
+		return nil, fmt.Errorf(comparatorMismatchErr, sm.cmpName, string(cmpName))
+	}
+
+	keyCodec, valCodec := setCodec(sm.keyCodec), setCodec(sm.valCodec)
+
+	// Corrupted counts are caught by the checksum, so the initial capacity is only a hint.
+	const maxInitialCap = 1 << 16
+	initialCap := count
+	if initialCap > maxInitialCap {
+		initialCap = maxInitialCap
+	}
+
+	recs := make([]Record, 0, initialCap)
+	for i := uint64(0); i < count; i++ {
+		rec := Record{}
+		if rec.Key, err = br.readDecoded(keyCodec.Decode); err != nil {
+			return nil, err
+		}
+		if rec.Val, err = br.readDecoded(valCodec.Decode); err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+
+	return recs, br.verifyChecksum()
+}
+
+// ReadFrom implements the io.ReaderFrom interface by reading a snapshot written by WriteTo,
+// replacing the contents of the collection.
+// If both the snapshot and the receiver have a name given to ComparatorName, the names must match.
+// Records are loaded in O(n) time because the snapshot is already sorted, and an error is returned
+// if they are not in order under the receiver's comparison function.
+// Corrupted or truncated snapshots return an error and leave the collection unchanged.
+// ReadFrom reads r in small pieces and never past the end of the snapshot, so file readers should be buffered.
+func (sm *SortedMap) ReadFrom(r io.Reader) (int64, error) {
+	br := newBinReader(r)
+
+	recs, err := sm.readSnapshot(br)
+	if err != nil {
+		return br.n, truncated(err)
+	}
+	return br.n, sm.loadSorted(recs)
+}
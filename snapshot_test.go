@@ -0,0 +1,168 @@
+package sortedmap
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
+
+func newSnapshotTestMap(t *testing.T) (*SortedMap, []byte) {
+	sm, _, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm.SetCodecs(StringCodec, TimeCodec)
+
+	buf := new(bytes.Buffer)
+	n, err := sm.WriteTo(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned an invalid byte count. Expected: %v, Had: %v.", buf.Len(), n)
+	}
+	return sm, buf.Bytes()
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	sm, data := newSnapshotTestMap(t)
+
+	decoded := New(0, asc.Time)
+	decoded.SetCodecs(StringCodec, TimeCodec)
+
+	n, err := decoded.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("TestSnapshotRoundTrip failed: invalid byte count. Expected: %v, Had: %v.", len(data), n)
+	}
+	if err := verifyEqualMaps(sm, decoded); err != nil {
+		t.Fatalf("TestSnapshotRoundTrip failed: %v", err)
+	}
+}
+
+func TestSnapshotRoundTripWithGobCodec(t *testing.T) {
+	sm := New(100, desc.Int)
+	for i := 0; i < 100; i++ {
+		sm.Insert(i, i%10)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := sm.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := New(0, desc.Int)
+	if _, err := decoded.ReadFrom(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyEqualMaps(sm, decoded); err != nil {
+		t.Fatalf("TestSnapshotRoundTripWithGobCodec failed: %v", err)
+	}
+}
+
+func TestSnapshotComparisonFuncMismatch(t *testing.T) {
+	_, data := newSnapshotTestMap(t)
+
+	decoded := New(0, desc.Time)
+	decoded.SetCodecs(StringCodec, TimeCodec)
+
+	if _, err := decoded.ReadFrom(bytes.NewReader(data)); err == nil {
+		t.Fatal("TestSnapshotComparisonFuncMismatch failed: a snapshot with a different comparison function was accepted.")
+	}
+}
+
+func TestSnapshotComparatorName(t *testing.T) {
+	newMap := func(opts ...Option) *SortedMap {
+		sm := New(0, func(i, j interface{}) bool {
+			return i.(int) < j.(int)
+		}, opts...)
+		sm.SetCodecs(StringCodec, IntCodec)
+		return sm
+	}
+	snapshot := func(sm *SortedMap) []byte {
+		sm.Insert("a", 1)
+		sm.Insert("b", 2)
+		buf := new(bytes.Buffer)
+		if _, err := sm.WriteTo(buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	// Equivalent anonymous comparison functions are accepted, because only the order is checked.
+	src := New(0, func(i, j interface{}) bool {
+		return i.(int) < j.(int)
+	})
+	src.SetCodecs(StringCodec, IntCodec)
+	unnamed := snapshot(src)
+	if _, err := newMap().ReadFrom(bytes.NewReader(unnamed)); err != nil {
+		t.Fatalf("TestSnapshotComparatorName failed: %v", err)
+	}
+	if _, err := newMap(ComparatorName("int asc")).ReadFrom(bytes.NewReader(unnamed)); err != nil {
+		t.Fatalf("TestSnapshotComparatorName failed: %v", err)
+	}
+
+	named := snapshot(newMap(ComparatorName("int asc")))
+	if _, err := newMap(ComparatorName("int asc")).ReadFrom(bytes.NewReader(named)); err != nil {
+		t.Fatalf("TestSnapshotComparatorName failed: %v", err)
+	}
+	if _, err := newMap(ComparatorName("priority")).ReadFrom(bytes.NewReader(named)); err == nil {
+		t.Fatal("TestSnapshotComparatorName failed: a snapshot with a different comparator name was accepted.")
+	}
+
+	renamed := newMap(ComparatorName("priority"))
+	renamed.SetComparisonFunc(asc.Int)
+	if _, err := renamed.ReadFrom(bytes.NewReader(named)); err != nil {
+		t.Fatalf("TestSnapshotComparatorName failed: SetComparisonFunc did not clear the name: %v", err)
+	}
+}
+
+func TestSnapshotCorrupted(t *testing.T) {
+	_, data := newSnapshotTestMap(t)
+
+	for _, i := range []int{0, 4, len(data) / 2, len(data) - 1} {
+		corrupted := append([]byte(nil), data...)
+		corrupted[i] ^= 0xff
+
+		decoded := New(0, asc.Time)
+		decoded.SetCodecs(StringCodec, TimeCodec)
+		decoded.Insert("existing", maxTime)
+
+		if _, err := decoded.ReadFrom(bytes.NewReader(corrupted)); err == nil {
+			t.Fatalf("TestSnapshotCorrupted failed: corruption at byte %v was not detected.", i)
+		}
+		if decoded.Len() != 1 {
+			t.Fatal("TestSnapshotCorrupted failed: the collection was modified.")
+		}
+	}
+}
+
+func TestSnapshotTruncated(t *testing.T) {
+	_, data := newSnapshotTestMap(t)
+
+	for _, n := range []int{0, 3, len(data) / 2, len(data) - 1} {
+		decoded := New(0, asc.Time)
+		decoded.SetCodecs(StringCodec, TimeCodec)
+
+		_, err := decoded.ReadFrom(bytes.NewReader(data[:n]))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("TestSnapshotTruncated failed: expected a truncation error at %v bytes, Had: %v", n, err)
+		}
+	}
+}
+
+func TestSnapshotEncodeError(t *testing.T) {
+	sm := New(1, asc.Int)
+	sm.Insert(1, 1)
+	sm.SetCodecs(StringCodec, IntCodec)
+
+	if _, err := sm.WriteTo(new(bytes.Buffer)); err == nil {
+		t.Fatal("TestSnapshotEncodeError failed: a key with an unsupported type was encoded.")
+	}
+}
@@ -1,11 +1,34 @@
 package sortedmap
 
+import "sort"
+
 // SortedMap contains a map, a slice, and references to one or more comparison functions.
 // SortedMap is not concurrency-safe, though it can be easily wrapped by a developer-defined type.
 type SortedMap struct {
 	idx    map[interface{}]interface{}
 	sorted []interface{}
 	lessFn ComparisonFunc
+	cmpFn  CompareFunc
+
+	jsonKeyFn,
+	jsonValFn DecodeFunc
+
+	keyCodec,
+	valCodec Codec
+
+	wal *WAL
+
+	indexes map[string]*Index
+
+	keySorted []interface{}
+	keyLessFn ComparisonFunc
+
+	cmpName       string
+	rejectNaN     bool
+	uniqueVals    bool
+	debugFn       func(err error)
+	agg           *aggTree
+	interpolateFn InterpolateFunc
 }
 
 // Record defines a type used in batching and iterations, where keys and values are used together.
@@ -28,14 +51,131 @@ func setComparisonFunc(cmpFn ComparisonFunc) ComparisonFunc {
 	return cmpFn
 }
 
+// CompareFunc defines the type of a three-way comparison function for the chosen value type, such as one wrapping cmp.Compare.
+// It returns a negative number when i is less than j, a positive number when i is greater than j, and zero otherwise.
+type CompareFunc func(i, j interface{}) int
+
+func noOpCompareFunc(_, _ interface{}) int {
+	return 0
+}
+
+func setCompareFunc(cmpFn CompareFunc) CompareFunc {
+	if cmpFn == nil {
+		return noOpCompareFunc
+	}
+	return cmpFn
+}
+
+// compareFromLess adapts a 'less than' function to a three-way comparison function.
+func compareFromLess(lessFn ComparisonFunc) CompareFunc {
+	return func(i, j interface{}) int {
+		if lessFn(i, j) {
+			return -1
+		}
+		if lessFn(j, i) {
+			return 1
+		}
+		return 0
+	}
+}
+
+// lessFromCompare adapts a three-way comparison function to a 'less than' function.
+func lessFromCompare(cmpFn CompareFunc) ComparisonFunc {
+	return func(i, j interface{}) bool {
+		return cmpFn(i, j) < 0
+	}
+}
+
+// ExtractFunc defines the type of function used to select the part of a value that a comparison function is applied to.
+type ExtractFunc func(val interface{}) interface{}
+
+// Then returns a comparison function that orders values using cmpFn, and then using next for values that cmpFn considers equal.
+func (cmpFn ComparisonFunc) Then(next ComparisonFunc) ComparisonFunc {
+	cmpFn, next = setComparisonFunc(cmpFn), setComparisonFunc(next)
+
+	return func(i, j interface{}) bool {
+		if cmpFn(i, j) {
+			return true
+		}
+		if cmpFn(j, i) {
+			return false
+		}
+		return next(i, j)
+	}
+}
+
+// ThenBy returns a comparison function that orders values using cmpFn,
+// and then by applying next to the part of each value selected by extract, for values that cmpFn considers equal.
+func (cmpFn ComparisonFunc) ThenBy(extract ExtractFunc, next ComparisonFunc) ComparisonFunc {
+	next = setComparisonFunc(next)
+
+	return cmpFn.Then(func(i, j interface{}) bool {
+		return next(extract(i), extract(j))
+	})
+}
+
 // New creates and initializes a new SortedMap structure and then returns a reference to it.
 // New SortedMaps are created with a backing map/slice of length/capacity n.
-func New(n int, cmpFn ComparisonFunc) *SortedMap {
-	return &SortedMap{
+// Options are applied in the order given.
+func New(n int, cmpFn ComparisonFunc, opts ...Option) *SortedMap {
+	lessFn := setComparisonFunc(cmpFn)
+	return newSortedMap(n, lessFn, compareFromLess(lessFn), opts)
+}
+
+// NewWithCompare creates and initializes a new SortedMap structure that orders values using a three-way comparison function,
+// and then returns a reference to it. Searches use the result of a single call to cmpFn to detect equal values,
+// where a 'less than' function given to New must be called twice.
+// New SortedMaps are created with a backing map/slice of length/capacity n.
+func NewWithCompare(n int, cmpFn CompareFunc, opts ...Option) *SortedMap {
+	cmpFn = setCompareFunc(cmpFn)
+	return newSortedMap(n, lessFromCompare(cmpFn), cmpFn, opts)
+}
+
+func newSortedMap(n int, lessFn ComparisonFunc, cmpFn CompareFunc, opts []Option) *SortedMap {
+	sm := &SortedMap{
 		idx:    make(map[interface{}]interface{}, n),
 		sorted: make([]interface{}, 0, n),
-		lessFn: setComparisonFunc(cmpFn),
+		lessFn: lessFn,
+		cmpFn:  cmpFn,
+	}
+	for _, opt := range opts {
+		opt(sm)
 	}
+	return sm
+}
+
+// SetComparisonFunc replaces the comparison function and re-sorts the collection in place, in O(n log n) time.
+// Records with equal values keep their existing order.
+// If a WAL is attached, Compact should be called afterwards, so that the log's snapshot records the new comparison function.
+func (sm *SortedMap) SetComparisonFunc(cmpFn ComparisonFunc) {
+	lessFn := setComparisonFunc(cmpFn)
+	sm.setOrder(lessFn, compareFromLess(lessFn))
+}
+
+// SetCompareFunc replaces the comparison function with a three-way comparison function,
+// and re-sorts the collection in the same way as SetComparisonFunc.
+func (sm *SortedMap) SetCompareFunc(cmpFn CompareFunc) {
+	cmpFn = setCompareFunc(cmpFn)
+	sm.setOrder(lessFromCompare(cmpFn), cmpFn)
+}
+
+func (sm *SortedMap) setOrder(lessFn ComparisonFunc, cmpFn CompareFunc) {
+	sm.lessFn, sm.cmpFn = lessFn, cmpFn
+	sm.cmpName = ""
+
+	sort.SliceStable(sm.sorted, func(i, j int) bool {
+		return sm.lessFn(sm.idx[sm.sorted[i]], sm.idx[sm.sorted[j]])
+	})
+	sm.resetAggregate()
+}
+
+// setRecords replaces the contents of the collection with an index and a slice of keys sorted by lessFn.
+func (sm *SortedMap) setRecords(idx map[interface{}]interface{}, sorted []interface{}) {
+	sm.idx = idx
+	sm.sorted = sorted
+	sm.resetAggregate()
+	sm.rebuildIndexes()
+	sm.rebuildKeyOrder()
 }
 
 // Len returns the number of items in the collection.
@@ -1,6 +1,11 @@
 package sortedmap
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/umpc/go-sortedmap/asc"
+	"github.com/umpc/go-sortedmap/desc"
+)
 
 const (
 	notFoundErr   = "key not found!"
@@ -39,3 +44,93 @@ func TestLen(t *testing.T) {
 		t.Fatalf("TestLen failed: invalid SortedMap length. Expected: %v, Had: %v.", count, sm.Len())
 	}
 }
+
+func TestSetComparisonFunc(t *testing.T) {
+	sm, records, err := newSortedMapFromRandRecords(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sm.SetComparisonFunc(desc.Time)
+	func() {
+		iterCh, err := sm.IterCh()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer iterCh.Close()
+
+		if err := verifyRecords(iterCh.Records(), true); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	sm.BatchReplace(records[:100])
+	sm.BatchDelete([]interface{}{records[100].Key, records[200].Key})
+
+	sm.SetComparisonFunc(asc.Time)
+	func() {
+		iterCh, err := sm.IterCh()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer iterCh.Close()
+
+		if err := verifyRecords(iterCh.Records(), false); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if sm.Len() != 298 {
+		t.Fatalf("TestSetComparisonFunc failed: Expected: 298 records, Had: %v.", sm.Len())
+	}
+}
+
+func TestSetComparisonFuncStableOrder(t *testing.T) {
+	sm := New(3, asc.Int)
+	sm.Insert("a", 1)
+	sm.Insert("b", 1)
+	sm.Insert("c", 2)
+
+	sm.SetComparisonFunc(desc.Int)
+	if err := verifyRecs(sm, []Record{
+		{"c", 2}, {"a", 1}, {"b", 1},
+	}); err != nil {
+		t.Fatalf("TestSetComparisonFuncStableOrder failed: %v", err)
+	}
+
+	sm.SetComparisonFunc(nil)
+	if sm.lessFn == nil {
+		t.Fatal("TestSetComparisonFuncStableOrder failed: lessFn was nil!")
+	}
+}
+
+func TestComparisonFuncThen(t *testing.T) {
+	byLen := ComparisonFunc(func(i, j interface{}) bool {
+		return len(i.(string)) < len(j.(string))
+	})
+	cmpFn := byLen.Then(asc.String)
+
+	if cmpFn("b", "a") || cmpFn("aa", "b") || cmpFn("a", "a") {
+		t.Fatal("TestComparisonFuncThen failed: invalid order.")
+	}
+	if !cmpFn("a", "b") || !cmpFn("b", "aa") {
+		t.Fatal("TestComparisonFuncThen failed: invalid order.")
+	}
+}
+
+func TestComparisonFuncThenBy(t *testing.T) {
+	first := func(v interface{}) interface{} {
+		return v.(string)[0]
+	}
+	byLen := ComparisonFunc(func(i, j interface{}) bool {
+		return len(i.(string)) < len(j.(string))
+	})
+	cmpFn := byLen.ThenBy(first, asc.Uint8)
+
+	if cmpFn("ba", "ab") || cmpFn("aa", "b") {
+		t.Fatal("TestComparisonFuncThenBy failed: invalid order.")
+	}
+	if !cmpFn("ab", "ba") || !cmpFn("b", "aa") {
+		t.Fatal("TestComparisonFuncThenBy failed: invalid order.")
+	}
+}
@@ -0,0 +1,447 @@
+package sortedmap
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Log file format:
+//
+//	magic     4 bytes, "SWAL"
+//	version   uvarint
+//	snapshot  the contents of the collection when the log was created or last compacted, as written by WriteTo
+//	entries   uvarint length, followed by a 4 byte CRC-32 (Castagnoli) of the length,
+//	          then the payload, followed by a 4 byte CRC-32 of all of the entry's preceding bytes
+//
+// Each payload starts with an operation byte, followed by its length-prefixed arguments.
+const (
+	walMagic   = "SWAL"
+	walVersion = 1
+)
+
+const (
+	walInsert byte = iota + 1
+	walReplace
+	walDelete
+	walBoundedDelete
+)
+
+const (
+	invalidWALMagicErr   = "Invalid log file: the magic number did not match."
+	unsupportedWALVerErr = "Unsupported log format version: %v"
+	corruptWALEntryErr   = "Corrupted log entry at offset %v: %v"
+	unknownWALOpErr      = "Unknown log operation: %v"
+)
+
+var errTornEntry = errors.New("Torn log entry.")
+
+// WALSyncPolicy defines when appended log entries are flushed to stable storage.
+type WALSyncPolicy int
+
+const (
+	// SyncAlways syncs the log file after every appended entry.
+	SyncAlways WALSyncPolicy = iota
+
+	// SyncInterval syncs the log file on the first append after SyncInterval has passed since the last sync.
+	SyncInterval
+
+	// SyncNever leaves flushing to the operating system. Compact and Close still sync the log file.
+	SyncNever
+)
+
+// WALParams contains configurable settings for OpenWAL.
+// SyncPolicy defaults to SyncAlways.
+type WALParams struct {
+	SyncPolicy   WALSyncPolicy
+	SyncInterval time.Duration
+}
+
+// WAL is an append-only write-ahead log that is attached to a SortedMap by OpenWAL.
+// Every successful Insert, Replace, Delete and BoundedDelete, including their batch variants, is appended to the log.
+// Keys and values are encoded using the codecs given to SetCodecs.
+type WAL struct {
+	sm       *SortedMap
+	path     string
+	file     *os.File
+	params   WALParams
+	lastSync time.Time
+	err      error
+}
+
+func writeWALHeader(w io.Writer, sm *SortedMap) error {
+	bw := newBinWriter(w)
+	bw.write([]byte(walMagic))
+	bw.writeUvarint(walVersion)
+	if bw.err != nil {
+		return bw.err
+	}
+	_, err := sm.WriteTo(w)
+	return err
+}
+
+// writeWALFile atomically replaces the file at path with a new log containing only a snapshot of sm.
+func writeWALFile(path string, sm *SortedMap) error {
+	tmpPath := path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	buf := bufio.NewWriter(f)
+	err = writeWALHeader(buf, sm)
+	if err == nil {
+		err = buf.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	// Some platforms do not support syncing directories.
+	d.Sync()
+	return nil
+}
+
+// readWALEntry reads the next entry of a log file.
+// Because the length of each entry is covered by its own checksum, an entry is only treated as torn
+// when it is cut short by the end of the file, or when a checksum mismatch is found in the final bytes of the file.
+func readWALEntry(r *bufio.Reader) ([]byte, int64, error) {
+	br := newBinReader(r)
+
+	// isTorn reports whether err was caused by a write that was interrupted at the end of the file.
+	isTorn := func(err error) bool {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return true
+		}
+		_, peekErr := r.Peek(1)
+		return peekErr == io.EOF
+	}
+
+	l, err := br.readUvarint()
+	if err == io.EOF && br.n == 0 {
+		return nil, 0, io.EOF
+	}
+	if err == nil {
+		err = br.verifyChecksum()
+	}
+	if err != nil {
+		if isTorn(err) {
+			return nil, br.n, errTornEntry
+		}
+		return nil, br.n, err
+	}
+
+	payload := new(bytes.Buffer)
+	if _, err := io.CopyN(payload, br, int64(l)); err != nil {
+		if err == io.EOF {
+			return nil, br.n, errTornEntry
+		}
+		return nil, br.n, err
+	}
+
+	if err := br.verifyChecksum(); err != nil {
+		if isTorn(err) {
+			return nil, br.n, errTornEntry
+		}
+		return nil, br.n, err
+	}
+
+	return payload.Bytes(), br.n, nil
+}
+
+func (sm *SortedMap) readWALBound(br *binReader, decFn DecodeFunc) (interface{}, error) {
+	isSet, err := br.ReadByte()
+	if err != nil || isSet == 0 {
+		return nil, err
+	}
+	return br.readDecoded(decFn)
+}
+
+func (sm *SortedMap) applyWALEntry(payload []byte) error {
+	keyCodec, valCodec := setCodec(sm.keyCodec), setCodec(sm.valCodec)
+	br := newBinReader(bytes.NewReader(payload))
+
+	op, err := br.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch op {
+	case walInsert, walReplace:
+		key, err := br.readDecoded(keyCodec.Decode)
+		if err != nil {
+			return err
+		}
+		val, err := br.readDecoded(valCodec.Decode)
+		if err != nil {
+			return err
+		}
+		if op == walInsert {
+			sm.insert(key, val)
+		} else {
+			sm.replace(key, val)
+		}
+
+	case walDelete:
+		key, err := br.readDecoded(keyCodec.Decode)
+		if err != nil {
+			return err
+		}
+		sm.delete(key)
+
+	case walBoundedDelete:
+		lowerBound, err := sm.readWALBound(br, valCodec.Decode)
+		if err != nil {
+			return err
+		}
+		upperBound, err := sm.readWALBound(br, valCodec.Decode)
+		if err != nil {
+			return err
+		}
+		sm.boundedDelete(lowerBound, upperBound)
+
+	default:
+		return fmt.Errorf(unknownWALOpErr, op)
+	}
+
+	return nil
+}
+
+// replayWAL loads the snapshot and replays the entries of a log file,
+// returning the offset of the end of the last complete entry.
+func (sm *SortedMap) replayWAL(f *os.File) (int64, error) {
+	r := bufio.NewReader(f)
+	br := newBinReader(r)
+
+	magic := make([]byte, len(walMagic))
+	if err := br.readFull(magic); err != nil {
+		return 0, truncated(err)
+	}
+	if string(magic) != walMagic {
+		return 0, errors.New(invalidWALMagicErr)
+	}
+	version, err := br.readUvarint()
+	if err != nil {
+		return 0, truncated(err)
+	}
+	if version != walVersion {
+		return 0, fmt.Errorf(unsupportedWALVerErr, version)
+	}
+
+	if _, err := sm.ReadFrom(br); err != nil {
+		return 0, err
+	}
+
+	offset := br.n
+	for {
+		payload, n, err := readWALEntry(r)
+		if err == io.EOF || err == errTornEntry {
+			return offset, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf(corruptWALEntryErr, offset, err)
+		}
+		if err := sm.applyWALEntry(payload); err != nil {
+			return 0, fmt.Errorf(corruptWALEntryErr, offset, err)
+		}
+		offset += n
+	}
+}
+
+// OpenWAL attaches a write-ahead log stored at path to the collection.
+// If the file exists, its snapshot and entries are replayed into the collection, replacing its contents,
+// and a torn final entry left behind by a crash is truncated. Otherwise, a new log is created containing the current contents.
+// If the log is corrupted, an error is returned and the collection is left unchanged.
+// Changes made by GobDecode, UnmarshalJSON and ReadFrom are not logged and should be followed by a call to Compact.
+func (sm *SortedMap) OpenWAL(path string, params WALParams) (*WAL, error) {
+	const walAttachedErr = "A log is already attached to the collection."
+
+	if sm.wal != nil {
+		return nil, errors.New(walAttachedErr)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if os.IsNotExist(err) {
+		if err := writeWALFile(path, sm); err != nil {
+			return nil, err
+		}
+		f, err = os.OpenFile(path, os.O_RDWR, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The log is replayed into an empty copy, so that a corrupted log leaves the collection unchanged.
+	replayed := sm.emptyCopy()
+	offset, err := replayed.replayWAL(f)
+	if err == nil {
+		err = f.Truncate(offset)
+	}
+	if err == nil {
+		_, err = f.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	sm.setRecords(replayed.idx, replayed.sorted)
+
+	sm.wal = &WAL{
+		sm:       sm,
+		path:     path,
+		file:     f,
+		params:   params,
+		lastSync: time.Now(),
+	}
+	return sm.wal, nil
+}
+
+func (wal *WAL) append(op byte, args ...interface{}) {
+	if wal == nil || wal.err != nil {
+		return
+	}
+	keyCodec, valCodec := setCodec(wal.sm.keyCodec), setCodec(wal.sm.valCodec)
+
+	payload := new(bytes.Buffer)
+	pw := newBinWriter(payload)
+	pw.write([]byte{op})
+
+	switch op {
+	case walInsert, walReplace:
+		pw.writeEncoded(keyCodec.Encode, args[0])
+		pw.writeEncoded(valCodec.Encode, args[1])
+
+	case walDelete:
+		pw.writeEncoded(keyCodec.Encode, args[0])
+
+	case walBoundedDelete:
+		for _, bound := range args {
+			if bound == nil {
+				pw.write([]byte{0})
+				continue
+			}
+			pw.write([]byte{1})
+			pw.writeEncoded(valCodec.Encode, bound)
+		}
+	}
+	if pw.err != nil {
+		wal.err = pw.err
+		return
+	}
+
+	entry := new(bytes.Buffer)
+	ew := newBinWriter(entry)
+	ew.writeUvarint(uint64(payload.Len()))
+	ew.writeChecksum()
+	ew.write(payload.Bytes())
+	ew.writeChecksum()
+
+	if _, err := wal.file.Write(entry.Bytes()); err != nil {
+		wal.err = err
+		return
+	}
+
+	switch wal.params.SyncPolicy {
+	case SyncAlways:
+		wal.err = wal.sync()
+
+	case SyncInterval:
+		if time.Since(wal.lastSync) >= wal.params.SyncInterval {
+			wal.err = wal.sync()
+		}
+	}
+}
+
+func (wal *WAL) logInsert(key, val interface{}) {
+	wal.append(walInsert, key, val)
+}
+
+func (wal *WAL) logReplace(key, val interface{}) {
+	wal.append(walReplace, key, val)
+}
+
+func (wal *WAL) logDelete(key interface{}) {
+	wal.append(walDelete, key)
+}
+
+func (wal *WAL) logBoundedDelete(lowerBound, upperBound interface{}) {
+	wal.append(walBoundedDelete, lowerBound, upperBound)
+}
+
+func (wal *WAL) sync() error {
+	wal.lastSync = time.Now()
+	return wal.file.Sync()
+}
+
+// Err returns the first error that occurred while appending to the log.
+// Once an error occurs, later changes are no longer logged, though they are still applied to the collection.
+func (wal *WAL) Err() error {
+	return wal.err
+}
+
+// Sync flushes appended entries to stable storage.
+func (wal *WAL) Sync() error {
+	if wal.err != nil {
+		return wal.err
+	}
+	return wal.sync()
+}
+
+// Compact atomically replaces the log with a fresh snapshot of the collection, discarding all appended entries.
+// A successful Compact clears any error returned by Err.
+func (wal *WAL) Compact() error {
+	if err := writeWALFile(wal.path, wal.sm); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(wal.path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		wal.err = err
+		return err
+	}
+	wal.file.Close()
+
+	wal.file = f
+	wal.lastSync = time.Now()
+	wal.err = nil
+
+	return nil
+}
+
+// Close syncs and closes the log file and detaches the log from the collection.
+func (wal *WAL) Close() error {
+	if wal.sm.wal == wal {
+		wal.sm.wal = nil
+	}
+
+	err := wal.file.Sync()
+	if closeErr := wal.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,304 @@
+package sortedmap
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/umpc/go-sortedmap/asc"
+)
+
+func newWALTestMap() *SortedMap {
+	sm := New(0, asc.Time)
+	sm.SetCodecs(StringCodec, TimeCodec)
+	return sm
+}
+
+func openWALTestMap(t *testing.T, path string, params WALParams) (*SortedMap, *WAL) {
+	sm := newWALTestMap()
+	wal, err := sm.OpenWAL(path, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sm, wal
+}
+
+func TestWALReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm, wal := openWALTestMap(t, path, WALParams{})
+
+	records := randRecords(300)
+	sm.BatchInsert(records[:200])
+	for _, rec := range records[:50] {
+		sm.Delete(rec.Key)
+	}
+	sm.BatchReplace(records[100:])
+	sm.Replace(records[150].Key, maxTime)
+	if err := sm.BoundedDelete(nil, time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wal.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, wal := openWALTestMap(t, path, WALParams{})
+	defer wal.Close()
+
+	if err := verifyEqualMaps(sm, replayed); err != nil {
+		t.Fatalf("TestWALReplay failed: %v", err)
+	}
+}
+
+func TestWALExistingRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm := newWALTestMap()
+	sm.BatchInsert(randRecords(100))
+
+	wal, err := sm.OpenWAL(path, WALParams{SyncPolicy: SyncNever})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sm.OpenWAL(path, WALParams{}); err == nil {
+		t.Fatal("TestWALExistingRecords failed: a second log was attached.")
+	}
+	sm.Insert("new", maxTime)
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, wal := openWALTestMap(t, path, WALParams{})
+	defer wal.Close()
+
+	if err := verifyEqualMaps(sm, replayed); err != nil {
+		t.Fatalf("TestWALExistingRecords failed: %v", err)
+	}
+}
+
+func TestWALTornEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm, wal := openWALTestMap(t, path, WALParams{SyncPolicy: SyncInterval, SyncInterval: time.Hour})
+
+	records := randRecords(10)
+	sm.BatchInsert(records[:9])
+	if err := wal.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm.Insert(records[9].Key, records[9].Val)
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cut := range []int64{1, 5, 12} {
+		fullInfo, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Truncate(path, fullInfo.Size()-cut); err != nil {
+			t.Fatal(err)
+		}
+
+		replayed, wal := openWALTestMap(t, path, WALParams{})
+		if replayed.Len() != 9 {
+			t.Fatalf("TestWALTornEntry failed: Expected: 9 records, Had: %v.", replayed.Len())
+		}
+		if !replayed.Insert(records[9].Key, records[9].Val) {
+			t.Fatalf("TestWALTornEntry failed: %v", keyExistsErr)
+		}
+		if err := wal.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		fullInfo, err = os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fullInfo.Size() <= info.Size() {
+			t.Fatal("TestWALTornEntry failed: the torn entry was not truncated.")
+		}
+	}
+}
+
+func TestWALCorruptedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm, wal := openWALTestMap(t, path, WALParams{})
+
+	sm.BatchInsert(randRecords(10))
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the payload of the first entry, which is followed by more entries.
+	snapshotLen := len(walMagic) + 1
+	snap := newWALTestMap()
+	if n, err := snap.WriteTo(io.Discard); err == nil {
+		snapshotLen += int(n)
+	}
+	data[snapshotLen+1] ^= 0xff
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newWALTestMap().OpenWAL(path, WALParams{}); err == nil {
+		t.Fatal("TestWALCorruptedEntry failed: a corrupted entry was accepted.")
+	}
+}
+
+func TestWALCorruptedLength(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm, wal := openWALTestMap(t, path, WALParams{})
+
+	sm.BatchInsert(randRecords(5))
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the length of the first entry, so that it claims more bytes than the rest of the log contains.
+	snapshotLen := len(walMagic) + 1
+	if n, err := newWALTestMap().WriteTo(io.Discard); err == nil {
+		snapshotLen += int(n)
+	}
+	data[snapshotLen] = 0x7f
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newWALTestMap().OpenWAL(path, WALParams{}); err == nil {
+		t.Fatal("TestWALCorruptedLength failed: a corrupted length was treated as a torn entry.")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len(data)) {
+		t.Fatalf("TestWALCorruptedLength failed: the log was truncated from %v to %v bytes.", len(data), info.Size())
+	}
+}
+
+func TestWALCorruptedEntryLeavesMapUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm, wal := openWALTestMap(t, path, WALParams{})
+
+	// Entries with the same key and value lengths have the same size.
+	for _, key := range []string{"a", "b", "c"} {
+		sm.Insert(key, maxTime)
+	}
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotLen := len(walMagic) + 1
+	if n, err := newWALTestMap().WriteTo(io.Discard); err == nil {
+		snapshotLen += int(n)
+	}
+	entryLen := (len(data) - snapshotLen) / 3
+	data[snapshotLen+entryLen+entryLen/2] ^= 0xff
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := newWALTestMap()
+	replayed.Insert("existing", maxTime)
+	if _, err := replayed.OpenWAL(path, WALParams{}); err == nil {
+		t.Fatal("TestWALCorruptedEntryLeavesMapUnchanged failed: a corrupted entry was accepted.")
+	}
+	if keys := replayed.Keys(); len(keys) != 1 || keys[0] != "existing" {
+		t.Fatalf("TestWALCorruptedEntryLeavesMapUnchanged failed: the collection was modified: %v", keys)
+	}
+}
+
+func TestWALInvalidHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	for _, data := range []string{"", "SWA", "XWAL\x01", "SWAL\x02"} {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := newWALTestMap().OpenWAL(path, WALParams{}); err == nil {
+			t.Fatalf("TestWALInvalidHeader failed: an invalid header was accepted: %q", data)
+		}
+	}
+}
+
+func TestWALCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm, wal := openWALTestMap(t, path, WALParams{})
+
+	records := randRecords(100)
+	for i := 0; i < 5; i++ {
+		sm.BatchReplace(records)
+	}
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wal.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Size() >= before.Size() {
+		t.Fatal("TestWALCompact failed: the log was not compacted.")
+	}
+
+	sm.Delete(records[0].Key)
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, wal := openWALTestMap(t, path, WALParams{})
+	defer wal.Close()
+
+	if err := verifyEqualMaps(sm, replayed); err != nil {
+		t.Fatalf("TestWALCompact failed: %v", err)
+	}
+}
+
+func TestWALEncodeError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sortedmap.wal")
+	sm, wal := openWALTestMap(t, path, WALParams{})
+	defer wal.Close()
+
+	sm.Insert(1, time.Now())
+	if wal.Err() == nil {
+		t.Fatal("TestWALEncodeError failed: an unsupported key type was logged.")
+	}
+	if wal.Sync() == nil {
+		t.Fatal("TestWALEncodeError failed: Sync did not return the log error.")
+	}
+
+	sm.Delete(1)
+	if err := wal.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if err := wal.Err(); err != nil {
+		t.Fatalf("TestWALEncodeError failed: Compact did not clear the log error: %v", err)
+	}
+}